@@ -89,19 +89,22 @@ func BarChartXYs(xys [][2]int) BarChart {
 	if len(xys) == 0 {
 		return BarChart{}
 	}
-	minx, maxx, miny, maxy := xys[0][0], xys[0][0], xys[0][1], xys[0][1]
-	plot := BarChart{
-		xy: make([]XY, len(xys)),
+	xy := make([]XY, len(xys))
+	for i := range xy {
+		xy[i] = XY{xys[i][0], xys[i][1]}
 	}
-	var x, y int
-	for i := range plot.xy {
-		x = xys[i][0]
-		y = xys[i][1]
-		minx = imin(x, minx)
-		maxx = imax(x, maxx)
-		miny = imin(y, miny)
-		maxy = imax(y, maxy)
-		plot.xy[i] = XY{x, y}
+	return newBarChart(xy)
+}
+
+// newBarChart builds a BarChart bounding xy, which must not be empty.
+func newBarChart(xy []XY) BarChart {
+	minx, maxx, miny, maxy := xy[0].X, xy[0].X, xy[0].Y, xy[0].Y
+	plot := BarChart{xy: xy}
+	for _, p := range xy {
+		minx = imin(p.X, minx)
+		maxx = imax(p.X, maxx)
+		miny = imin(p.Y, miny)
+		maxy = imax(p.Y, maxy)
 	}
 	plot.MinX = minx
 	plot.MaxX = maxx
@@ -2,8 +2,10 @@ package barchart
 
 import (
 	"github.com/dustin/go-humanize"
+	"math"
 	"math/rand"
 	"os"
+	"testing"
 	"time"
 )
 
@@ -106,3 +108,56 @@ func ExampleBarChartXYs_time() {
 	// 19:00:01.384  ████████▎ 15MB
 	// 19:00:01.500  ███▌ 8.0MB
 }
+
+func ExampleLog() {
+	data := [][2]int{
+		{0, 2},
+		{1, 3},
+		{2, 5},
+		{3, 4000},
+		{4, 8},
+		{5, 1},
+	}
+
+	plot := BarChartXYs(data)
+
+	if err := Fprint(os.Stdout, plot, Log(10), ScaleLegend("log")); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0  ▉ 2
+	// 1  █▍ 3
+	// 2  ██ 5
+	// 3  ██████████▏ 4000
+	// 4  ██▋ 8
+	// 5  ▏ 1
+	// scale: log
+}
+
+func ExampleSqrt() {
+	// all the bars are equal
+	plot := BarChartXYs([][2]int{{0, 4}, {1, 4}, {2, 4}})
+
+	if err := Fprint(os.Stdout, plot, Sqrt(5), ScaleLegend("sqrt")); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0  █████▏ 4
+	// 1  █████▏ 4
+	// 2  █████▏ 4
+	// scale: sqrt
+}
+
+func TestClampInvalidPercentile(t *testing.T) {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 2}, {2, 3}})
+	for _, percentile := range []float64{-1, 150, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("percentile=%v: want a panic", percentile)
+				}
+			}()
+			Clamp(10, percentile, plot)
+		}()
+	}
+}
@@ -0,0 +1,193 @@
+package barchart
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// codecVersion is the version of the JSON, CSV and binary encodings
+// of a BarChart. Decoders reject encodings of a newer version.
+const codecVersion = 1
+
+var (
+	// ErrUnsupportedVersion is returned when decoding a barchart
+	// encoded by a newer version of this package.
+	ErrUnsupportedVersion = errors.New("barchart: unsupported encoding version")
+	// ErrInvalidEncoding is returned when decoding data that isn't
+	// an encoded barchart.
+	ErrInvalidEncoding = errors.New("barchart: invalid encoding")
+)
+
+// checkVersion tells whether encodings of version v can be decoded.
+// Versions start at 1, so a missing version is invalid.
+func checkVersion(v int) error {
+	switch {
+	case v < 1:
+		return ErrInvalidEncoding
+	case v > codecVersion:
+		return ErrUnsupportedVersion
+	}
+	return nil
+}
+
+// csvHeader names the columns of the CSV encoding.
+var csvHeader = []string{"x", "y"}
+
+// Points gives a copy of the XY points added to p, in the order they
+// were added.
+func (p BarChart) Points() []XY { return append([]XY(nil), p.xy...) }
+
+// The bounds of a BarChart are computed from its points when it's
+// decoded, so they aren't encoded.
+
+type jsonBarChart struct {
+	Version int      `json:"version"`
+	Points  [][2]int `json:"points"`
+}
+
+// MarshalJSON encodes the points of p as [x, y] pairs.
+func (p BarChart) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonBarChart{Version: codecVersion, Points: pairs(p.xy)})
+}
+
+// UnmarshalJSON decodes a barchart encoded by MarshalJSON.
+func (p *BarChart) UnmarshalJSON(data []byte) error {
+	var jp jsonBarChart
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	if err := checkVersion(jp.Version); err != nil {
+		return err
+	}
+	*p = BarChartXYs(jp.Points)
+	return nil
+}
+
+func pairs(xys []XY) [][2]int {
+	out := make([][2]int, len(xys))
+	for i, xy := range xys {
+		out[i] = [2]int{xy.X, xy.Y}
+	}
+	return out
+}
+
+// WriteCSV writes the points of p on w as CSV, with an `x,y` row per
+// point. The rows are preceded by a comment line holding the version
+// of the encoding:
+//
+//	# barchart v1
+//	x,y
+//	0,1
+//	1,3
+func WriteCSV(w io.Writer, p BarChart) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# barchart v%d\n", codecVersion)
+	csvw := csv.NewWriter(bw)
+	csvw.Write(csvHeader)
+	for _, xy := range p.xy {
+		csvw.Write([]string{strconv.Itoa(xy.X), strconv.Itoa(xy.Y)})
+	}
+	csvw.Flush()
+	if err := csvw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads a barchart written by WriteCSV.
+func ReadCSV(r io.Reader) (BarChart, error) {
+	br := bufio.NewReader(r)
+	comment, err := br.ReadString('\n')
+	if err != nil {
+		return BarChart{}, ErrInvalidEncoding
+	}
+	var version int
+	if _, err := fmt.Sscanf(strings.TrimSpace(comment), "# barchart v%d", &version); err != nil {
+		return BarChart{}, ErrInvalidEncoding
+	}
+	if err := checkVersion(version); err != nil {
+		return BarChart{}, err
+	}
+
+	csvr := csv.NewReader(br)
+	csvr.FieldsPerRecord = 2
+	records, err := csvr.ReadAll()
+	if err != nil {
+		return BarChart{}, err
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return BarChart{}, ErrInvalidEncoding
+	}
+	xys := make([][2]int, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		var xy [2]int
+		for i, field := range rec {
+			if xy[i], err = strconv.Atoi(field); err != nil {
+				return BarChart{}, ErrInvalidEncoding
+			}
+		}
+		xys = append(xys, xy)
+	}
+	return BarChartXYs(xys), nil
+}
+
+// binaryMagic starts the binary encoding of a BarChart.
+const binaryMagic = "UPB"
+
+// MarshalBinary encodes p in a compact binary form: a magic header
+// and a version byte, followed by the number of points and the
+// varint encoded X and Y of every point.
+func (p BarChart) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(codecVersion)
+
+	var scratch [binary.MaxVarintLen64]byte
+	buf.Write(scratch[:binary.PutUvarint(scratch[:], uint64(len(p.xy)))])
+	for _, xy := range p.xy {
+		buf.Write(scratch[:binary.PutVarint(scratch[:], int64(xy.X))])
+		buf.Write(scratch[:binary.PutVarint(scratch[:], int64(xy.Y))])
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a barchart encoded by MarshalBinary.
+func (p *BarChart) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidEncoding
+	}
+	if err := checkVersion(int(data[len(binaryMagic)])); err != nil {
+		return err
+	}
+	r := bytes.NewReader(data[len(binaryMagic)+1:])
+
+	n, err := binary.ReadUvarint(r)
+	// every point takes at least 2 bytes
+	if err != nil || n > uint64(r.Len()/2) {
+		return ErrInvalidEncoding
+	}
+	xys := make([][2]int, n)
+	for i := range xys {
+		for j := range xys[i] {
+			v, err := binary.ReadVarint(r)
+			if err != nil || v < math.MinInt || v > math.MaxInt {
+				return ErrInvalidEncoding
+			}
+			xys[i][j] = int(v)
+		}
+	}
+	if r.Len() > 0 {
+		return ErrInvalidEncoding
+	}
+	*p = BarChartXYs(xys)
+	return nil
+}
@@ -0,0 +1,77 @@
+package barchart
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func ExampleBarChart_MarshalJSON() {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 3}, {3, 2}})
+	data, err := json.Marshal(plot)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+
+	var decoded BarChart
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		panic(err)
+	}
+	fmt.Println(decoded.Points(), decoded.MinX, decoded.MaxX)
+	// Output:
+	// {"version":1,"points":[[0,1],[1,3],[3,2]]}
+	// [{0 1} {1 3} {3 2}] 0 3
+}
+
+func ExampleWriteCSV() {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 3}, {3, 2}})
+	if err := WriteCSV(os.Stdout, plot); err != nil {
+		panic(err)
+	}
+	// Output:
+	// # barchart v1
+	// x,y
+	// 0,1
+	// 1,3
+	// 3,2
+}
+
+func ExampleBarChart_MarshalBinary() {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 3}, {3, 2}})
+	data, err := plot.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(len(data), "bytes")
+
+	var decoded BarChart
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	fmt.Println(decoded.Points())
+	// Output:
+	// 11 bytes
+	// [{0 1} {1 3} {3 2}]
+}
+
+func ExampleReadCSV_invalid() {
+	for _, data := range []string{
+		"# barchart v0\nx,y\n0,1\n",
+		"# barchart v1\n0,1\n",
+		"# barchart v2\nx,y\n0,1\n",
+	} {
+		_, err := ReadCSV(strings.NewReader(data))
+		fmt.Println(err)
+	}
+	var p BarChart
+	fmt.Println(json.Unmarshal([]byte(`{"points":[[0,1]]}`), &p))
+	fmt.Println(p.UnmarshalBinary([]byte("UPB\x00\x00")))
+	// Output:
+	// barchart: invalid encoding
+	// barchart: invalid encoding
+	// barchart: unsupported encoding version
+	// barchart: invalid encoding
+	// barchart: invalid encoding
+}
@@ -0,0 +1,63 @@
+package barchart
+
+import (
+	"io"
+	"math"
+	"unicode/utf8"
+
+	"github.com/aybabtme/uniplot/internal/term"
+)
+
+// FitWidth sizes the bars so that the widest line printed by Fprint or
+// Fprintf spans exactly `width` columns, labels and values included.
+// The shape of the bars given by the ScaleFunc is kept, only their
+// length changes.
+func FitWidth(width int) Option {
+	return func(o *options) { o.fitWidth = width }
+}
+
+// FitTerminal sizes the bars like FitWidth, so that the widest line,
+// its value included, ends at the right edge of the terminal the
+// barchart is printed on. When printing elsewhere, the terminal of the
+// standard output is used, or 80 columns if there's none.
+func FitTerminal() Option {
+	return func(o *options) { o.fitTerminal = true }
+}
+
+// lineWidth gives the width lines printed on w must fit in, or 0 if
+// they needn't fit.
+func (o options) lineWidth(w io.Writer) int {
+	if o.fitTerminal {
+		return term.Width(w)
+	}
+	return o.fitWidth
+}
+
+// fitBars rescales the bars of xys so that the widest of their lines
+// spans width columns. The values are printed right after the bars,
+// so each line limits how long its bar can be.
+func fitBars(xys []XYf, labels, values []string, padding, width int) {
+	// like the tabwriter lays out the labels column
+	column := 0
+	for _, l := range labels {
+		column = imax(column, utf8.RuneCountInString(l)+padding)
+	}
+
+	k := math.Inf(1)
+	for i, xy := range xys {
+		if xy.Y == nil || !(*xy.ScaledY > 0) {
+			continue
+		}
+		// a bar is a glyph longer than its size
+		room := width - column - utf8.RuneCountInString(values[i]) - 1
+		k = math.Min(k, math.Max(0, float64(room))/(*xy.ScaledY))
+	}
+	if math.IsInf(k, 1) {
+		return
+	}
+	for _, xy := range xys {
+		if xy.ScaledY != nil {
+			*xy.ScaledY *= k
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package barchart
+
+import (
+	"os"
+)
+
+func ExampleFitWidth() {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 3}, {2, 40}, {3, 12000}, {4, 9000}})
+	// each line is 30 columns at most
+	if err := Fprint(os.Stdout, plot, Linear(100), FitWidth(30)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0  ▏ 1
+	// 1  ▏ 3
+	// 2  ▏ 40
+	// 3  ████████████████████▏ 12000
+	// 4  ███████████████ 9000
+}
@@ -0,0 +1,53 @@
+package barchart
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aybabtme/uniplot/internal/numeric"
+)
+
+// Number is the constraint of the X and Y values that Of can plot.
+// It includes time.Duration and other types defined on numbers.
+type Number = numeric.Number
+
+// Of builds a BarChart out of pairwise xs and ys, like BarChartXYs,
+// without converting them to [][2]int first. Since a BarChart holds
+// ints, floats are rounded to the nearest int, and Of panics on values
+// that don't fit in an int. It also gives FormatFuncs suited to print
+// the values of X and Y, see FormatOf:
+//
+//	plot, xfmt, yfmt := Of(offsets, latencies) // latencies is a []time.Duration
+//	err := Fprintf(os.Stdout, plot, 20, Linear(10), xfmt, yfmt)
+func Of[X, Y Number](xs []X, ys []Y) (BarChart, FormatFunc, FormatFunc) {
+	if len(xs) != len(ys) {
+		log.Panicf("barchart: got %d xs but %d ys", len(xs), len(ys))
+	}
+	xfmt, yfmt := FormatOf[X](), FormatOf[Y]()
+	if len(xs) == 0 {
+		return BarChart{}, xfmt, yfmt
+	}
+	xy := make([]XY, len(xs))
+	for i := range xy {
+		x, xok := numeric.ToInt(xs[i])
+		y, yok := numeric.ToInt(ys[i])
+		if !xok || !yok {
+			log.Panicf("barchart: value out of the range of int, x=%v\ty=%v", xs[i], ys[i])
+		}
+		xy[i] = XY{x, y}
+	}
+	return newBarChart(xy), xfmt, yfmt
+}
+
+// FormatOf gives a FormatFunc suited to print values of T. Durations
+// print like `1.2ms`, with 3 significant digits, and other numbers
+// like they do in Fprint.
+func FormatOf[T Number]() FormatFunc {
+	var zero T
+	switch any(zero).(type) {
+	case time.Duration:
+		return numeric.FormatDuration
+	}
+	return func(v float64) string { return fmt.Sprintf("%v", v) }
+}
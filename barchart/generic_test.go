@@ -0,0 +1,57 @@
+package barchart
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+func ExampleOf() {
+	attempts := []uint8{1, 2, 3, 4, 5}
+	latencies := []time.Duration{
+		1200 * time.Microsecond,
+		2500 * time.Microsecond,
+		1800 * time.Microsecond,
+		5 * time.Millisecond,
+		3300 * time.Microsecond,
+	}
+	plot, xfmt, yfmt := Of(attempts, latencies)
+	if err := Fprintf(os.Stdout, plot, 5, Linear(10), xfmt, yfmt); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1  ▏ 1.2ms
+	// 2  ███▌ 2.5ms
+	// 3  █▋ 1.8ms
+	// 4  ██████████▏ 5ms
+	// 5  █████▋ 3.3ms
+}
+
+func ExampleOf_float() {
+	plot, xfmt, yfmt := Of([]float64{1, 2, 3}, []float32{2.4, 5.6, 4})
+	if err := Fprintf(os.Stdout, plot, 3, Linear(6), xfmt, yfmt); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1  ▏ 2
+	// 2  ██████▏ 6
+	// 3  ███▏ 4
+}
+
+func TestOfOutOfRange(t *testing.T) {
+	for name, of := range map[string]func(){
+		"uint64": func() { Of([]int{1}, []uint64{math.MaxUint64}) },
+		"float":  func() { Of([]float64{1e30}, []int{1}) },
+		"NaN":    func() { Of([]float64{math.NaN()}, []int{1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Of didn't panic", name)
+				}
+			}()
+			of()
+		}()
+	}
+}
@@ -0,0 +1,46 @@
+package barchart
+
+// Option customizes how a barchart is printed.
+type Option func(*options)
+
+type options struct {
+	legend      string
+	fitWidth    int
+	fitTerminal bool
+
+	scale      ScaleFunc
+	width      int
+	xfmt, yfmt FormatFunc
+	hidden     uint
+	align      Alignment
+	ranged     bool
+	ranges     RangeStyle
+	separator  string
+	padding    *int
+	empty      *string
+	glyphs     GlyphSet
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ScaleLegend prints a legend under the chart naming the scale the
+// bars are drawn with, such as `scale: log`, so that readers know a
+// bar twice as long doesn't hold twice as many values. The name is
+// printed as given, for instance `log` or `linear, clamped at p80`.
+func ScaleLegend(name string) Option {
+	return func(o *options) { o.legend = name }
+}
+
+// legendLine gives the line to print under the chart, if any.
+func (o options) legendLine() string {
+	if o.legend == "" {
+		return ""
+	}
+	return "scale: " + o.legend + "\n"
+}
@@ -0,0 +1,196 @@
+package barchart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aybabtme/uniplot/internal/bars"
+)
+
+// Render plots p on w like Fprint, every aspect of the output being
+// set by opts. Without options, there's a line per X value, the bars
+// are scaled with Linear(40) and values are printed with a %v format.
+func Render(w io.Writer, p BarChart, opts ...Option) error {
+	o := newOptions(opts)
+	width := o.width
+	if width <= 0 {
+		width = p.MaxX - p.MinX + 1
+	}
+	s := o.scale
+	if s == nil {
+		s = Linear(40)
+	}
+	xfmt, yfmt := o.xfmt, o.yfmt
+	if xfmt == nil {
+		xfmt = defaultFormat
+	}
+	if yfmt == nil {
+		yfmt = defaultFormat
+	}
+	return fprintf(w, p, width, s, xfmt, yfmt, o)
+}
+
+func defaultFormat(v float64) string { return fmt.Sprintf("%v", v) }
+
+// WithScale scales the bars with s.
+func WithScale(s ScaleFunc) Option {
+	return func(o *options) { o.scale = s }
+}
+
+// WithWidth groups the X values over `width` lines.
+func WithWidth(width int) Option {
+	return func(o *options) { o.width = width }
+}
+
+// WithFormats prints the X values with x and the Y values with y.
+func WithFormats(x, y FormatFunc) Option {
+	return func(o *options) { o.xfmt, o.yfmt = x, y }
+}
+
+// Column is a column of the lines printed by Fprint.
+type Column int
+
+const (
+	// LabelColumn holds the X value of each line.
+	LabelColumn Column = iota
+	// ValueColumn holds the Y value of each line, after its bar.
+	ValueColumn
+)
+
+// HideColumns leaves cols out of the lines. The bars are always
+// printed.
+func HideColumns(cols ...Column) Option {
+	return func(o *options) {
+		for _, col := range cols {
+			o.hidden |= 1 << uint(col)
+		}
+	}
+}
+
+func (o options) shows(col Column) bool { return o.hidden&(1<<uint(col)) == 0 }
+
+// Alignment is how labels line up in their column.
+type Alignment int
+
+const (
+	// AlignLeft lines labels up on their left, which is the default.
+	AlignLeft Alignment = iota
+	// AlignRight lines labels up on their right, next to the bars.
+	AlignRight
+)
+
+// AlignLabels lines the X labels up according to a.
+func AlignLabels(a Alignment) Option {
+	return func(o *options) { o.align = a }
+}
+
+// RangeStyle is how the range of X values of a line is labelled.
+type RangeStyle int
+
+const (
+	// DashRanges labels lines like `10-20`.
+	DashRanges RangeStyle = iota
+	// IntervalRanges labels lines like `[10, 20)`.
+	IntervalRanges
+)
+
+// RangeLabels labels each line with the range of X values it sums,
+// in style, rather than with the lowest of them. It's most useful
+// when the chart is narrower than its X values.
+func RangeLabels(style RangeStyle) Option {
+	return func(o *options) { o.ranged, o.ranges = true, style }
+}
+
+// RangeSeparator separates the bounds of the ranges with sep instead
+// of a dash, in the DashRanges style.
+func RangeSeparator(sep string) Option {
+	return func(o *options) { o.separator = sep }
+}
+
+// Padding puts n spaces between the labels and the bars instead of 2.
+func Padding(n int) Option {
+	return func(o *options) {
+		n = imax(n, 0)
+		o.padding = &n
+	}
+}
+
+// EmptyMarker draws the lines without any value with marker, instead
+// of "nil".
+func EmptyMarker(marker string) Option {
+	return func(o *options) { o.empty = &marker }
+}
+
+// GlyphSet lists the glyphs that bars are drawn with, from the thinnest
+// to the full glyph. Glyph sets are shared with the histogram package.
+type GlyphSet = bars.Set
+
+var (
+	// Blocks draws bars with eighths of Unicode blocks, `▏▎▍▌▋▊▉█`.
+	// It's the default.
+	Blocks = bars.Blocks
+	// ASCII draws bars with ASCII characters, `.-=#`, for terminals
+	// and fonts that lack Unicode blocks.
+	ASCII = bars.ASCII
+)
+
+// Glyphs draws the bars of the X values with g, which must have at
+// least one glyph.
+func Glyphs(g GlyphSet) Option {
+	return func(o *options) {
+		if len(g) > 0 {
+			o.glyphs = g
+		}
+	}
+}
+
+// bar draws a bar of size v, or the empty marker if there's no value
+// to draw.
+func (o options) bar(xy XYf) string {
+	if xy.Y == nil {
+		if o.empty != nil {
+			return *o.empty
+		}
+		return "nil"
+	}
+	v := *xy.ScaledY
+	if math.IsNaN(v) {
+		v = 1.0
+	}
+	if o.glyphs != nil {
+		return o.glyphs.Bar(v)
+	}
+	return barstring(v)
+}
+
+// label names a line starting at x and spanning step X values.
+func (o options) label(x, step float64, f FormatFunc) string {
+	switch {
+	case !o.ranged:
+		return f(x)
+	case o.ranges == IntervalRanges:
+		return "[" + f(x) + ", " + f(x+step) + ")"
+	case o.separator != "":
+		return f(x) + o.separator + f(x+step)
+	}
+	return f(x) + "-" + f(x+step)
+}
+
+// alignLabels pads labels so that they line up as asked for.
+func (o options) alignLabels(labels []string) []string {
+	if o.align != AlignRight {
+		return labels
+	}
+	width := 0
+	for _, l := range labels {
+		width = imax(width, utf8.RuneCountInString(l))
+	}
+	aligned := make([]string, len(labels))
+	for i, l := range labels {
+		aligned[i] = strings.Repeat(" ", width-utf8.RuneCountInString(l)) + l
+	}
+	return aligned
+}
@@ -0,0 +1,41 @@
+package barchart
+
+import (
+	"os"
+)
+
+func ExampleRender() {
+	plot := BarChartXYs([][2]int{
+		{0, 2}, {1, 4}, {2, 6}, {3, 8},
+		{4, 6}, {9, 4}, {10, 2}, {12, 1},
+	})
+	err := Render(os.Stdout, plot,
+		WithScale(Linear(6)),
+		WithWidth(4),
+		RangeLabels(IntervalRanges),
+		AlignLabels(AlignRight),
+		Glyphs(ASCII),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	//   [0, 4)  ######. 20
+	//   [4, 8)  #= 6
+	//  [8, 12)  #= 6
+	// [12, 16)  . 1
+}
+
+func ExampleEmptyMarker() {
+	plot := BarChartXYs([][2]int{{0, 1}, {1, 3}, {4, 2}})
+	err := Fprint(os.Stdout, plot, Linear(3), EmptyMarker("·"), HideColumns(ValueColumn), Padding(1))
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0 ▏
+	// 1 ███▏
+	// 2 ·
+	// 3 ·
+	// 4 █▋
+}
@@ -0,0 +1,83 @@
+package barchart
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// Log builds a ScaleFunc that scales the values of a barchart on a
+// log(1+x) axis, so that small bars stay visible next to huge ones.
+// Values do not exceed width, and bars are full width when all the
+// values are equal.
+func Log(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		if min == max {
+			return float64(width)
+		}
+		return math.Log1p(value-min) / math.Log1p(max-min) * float64(width)
+	}
+}
+
+// Sqrt builds a ScaleFunc that scales the values of a barchart on a
+// square root axis, so that they do not exceed width. Bars are full
+// width when all the values are equal.
+func Sqrt(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		if min == max {
+			return float64(width)
+		}
+		return math.Sqrt(value-min) / math.Sqrt(max-min) * float64(width)
+	}
+}
+
+// SymLog builds a ScaleFunc that scales the values of a barchart on a
+// symmetric log axis, sign(x)·log(1+|x|), which suits values spanning
+// both sides of zero. Values do not exceed width, and bars are full
+// width when all the values are equal.
+func SymLog(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		lo, hi := symlog(min), symlog(max)
+		if lo == hi {
+			return float64(width)
+		}
+		return (symlog(value) - lo) / (hi - lo) * float64(width)
+	}
+}
+
+func symlog(x float64) float64 {
+	if x < 0 {
+		return -math.Log1p(-x)
+	}
+	return math.Log1p(x)
+}
+
+// Clamp builds a ScaleFunc that scales the values of a barchart
+// linearly, up to the given percentile of the Y values of p, in
+// [0, 100]. Bigger values are drawn at full width, so that a single
+// spike doesn't squash all the other bars. Clamp panics on percentiles
+// outside of [0, 100].
+func Clamp(width int, percentile float64, p BarChart) ScaleFunc {
+	if !(percentile >= 0 && percentile <= 100) {
+		log.Panicf("barchart: invalid clamp, percentile=%f", percentile)
+	}
+	ys := make([]float64, len(p.xy))
+	for i, xy := range p.xy {
+		ys[i] = float64(xy.Y)
+	}
+	sort.Float64s(ys)
+	linear := Linear(width)
+	ceil := math.Inf(-1)
+	if len(ys) > 0 {
+		pos := percentile / 100 * float64(len(ys)-1)
+		lo := int(math.Floor(pos))
+		hi := imin(lo+1, len(ys)-1)
+		ceil = ys[lo] + (pos-float64(lo))*(ys[hi]-ys[lo])
+	}
+	return func(min, max, value float64) float64 {
+		if ceil <= min {
+			return linear(min, max, value)
+		}
+		return linear(min, ceil, math.Min(value, ceil))
+	}
+}
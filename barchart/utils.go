@@ -42,10 +42,10 @@ var barstring = func(v float64) string {
 //    13  █ 1
 //    14  ▏ 0
 //    15  ███████████████████▏ 20
-func Fprint(w io.Writer, p BarChart, s ScaleFunc) error {
-	fmtFunc := func(v float64) string { return fmt.Sprintf("%v", v) }
-	width := p.MaxX - p.MinX + 1
-	return fprintf(w, p, width, s, fmtFunc, fmtFunc)
+//
+// The opts customize the output, see Option.
+func Fprint(w io.Writer, p BarChart, s ScaleFunc, opts ...Option) error {
+	return Render(w, p, append([]Option{WithScale(s)}, opts...)...)
 }
 
 // Fprintf plots p as a Unicode XY plot of width, scaling Y values with
@@ -65,33 +65,46 @@ func Fprint(w io.Writer, p BarChart, s ScaleFunc) error {
 //    12:06:01.367  ███▊ 7.0MB
 //    12:06:01.483  ███████████▏ 15MB
 //    12:06:01.598  ████▋ 8.0MB
-func Fprintf(w io.Writer, p BarChart, width int, s ScaleFunc, x, y FormatFunc) error {
-	return fprintf(w, p, width, s, x, y)
+func Fprintf(w io.Writer, p BarChart, width int, s ScaleFunc, x, y FormatFunc, opts ...Option) error {
+	return Render(w, p, append([]Option{WithWidth(width), WithScale(s), WithFormats(x, y)}, opts...)...)
 }
 
-func fprintf(w io.Writer, p BarChart, width int, s ScaleFunc, xfmt, yfmt FormatFunc) error {
-	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
-	for _, xy := range p.ScaleXYs(width, s) {
-		xstr := xfmt(xy.X)
-		var ystr string
-		var bar string
-		if xy.Y == nil {
-			ystr = ""
-			bar = "nil"
-		} else {
-			scaledY := *xy.ScaledY
-			if math.IsNaN(scaledY) {
-				scaledY = 1.0
-			}
-			bar = barstring(scaledY)
-			ystr = " " + yfmt(*xy.Y)
+func fprintf(w io.Writer, p BarChart, width int, s ScaleFunc, xfmt, yfmt FormatFunc, o options) error {
+	padding := 2
+	if o.padding != nil {
+		padding = *o.padding
+	}
+	tabw := tabwriter.NewWriter(w, 0, 2, padding, byte(' '), 0)
+	xys := p.ScaleXYs(width, s)
+
+	step := float64(p.MaxX-p.MinX) / float64(width-1)
+	labels := make([]string, len(xys))
+	values := make([]string, len(xys))
+	for i, xy := range xys {
+		labels[i] = o.label(xy.X, step, xfmt)
+		if xy.Y != nil && o.shows(ValueColumn) {
+			values[i] = " " + yfmt(*xy.Y)
 		}
+	}
+	labels = o.alignLabels(labels)
+	if !o.shows(LabelColumn) {
+		labels = nil
+	}
 
-		fmt.Fprintf(tabw, "%s\t%s\n",
-			xstr,
-			bar+ystr,
-		)
+	if lineWidth := o.lineWidth(w); lineWidth > 0 {
+		fitBars(xys, labels, values, padding, lineWidth)
+	}
+	for i, xy := range xys {
+		if labels == nil {
+			fmt.Fprintf(tabw, "%s\n", o.bar(xy)+values[i])
+			continue
+		}
+		fmt.Fprintf(tabw, "%s\t%s\n", labels[i], o.bar(xy)+values[i])
 	}
 
-	return tabw.Flush()
+	if err := tabw.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, o.legendLine())
+	return err
 }
@@ -0,0 +1,241 @@
+package histogram
+
+import (
+	"math"
+	"sort"
+)
+
+// BinRule picks a number of buckets suited to partition input.
+// HistAuto only gives it the finite values of its input.
+type BinRule func(input []float64) int
+
+// MaxAutoBins caps the number of buckets that HistAuto partitions
+// values over, whatever the rule picked. The rules sizing buckets by
+// their width would otherwise pick billions of them for a tight bulk
+// of values with a far outlier.
+const MaxAutoBins = 1000
+
+var (
+	// Sturges picks log2(n)+1 buckets. It works well for small,
+	// roughly normal data sets.
+	Sturges BinRule = sturges
+	// Scott picks buckets of width 3.49σ/∛n, which suits normal
+	// data sets.
+	Scott BinRule = scott
+	// FreedmanDiaconis picks buckets of width 2·IQR/∛n, which is
+	// robust to outliers.
+	FreedmanDiaconis BinRule = freedmanDiaconis
+	// SquareRoot picks √n buckets.
+	SquareRoot BinRule = squareRoot
+	// Doane improves on Sturges for skewed data sets.
+	Doane BinRule = doane
+)
+
+func sturges(input []float64) int {
+	if len(input) == 0 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(len(input))))) + 1
+}
+
+func scott(input []float64) int {
+	input = finite(input)
+	width := 3.49 * stddev(input) / math.Cbrt(float64(len(input)))
+	return binsOfWidth(input, width)
+}
+
+func freedmanDiaconis(input []float64) int {
+	input = finite(input)
+	sorted := sortedCopy(input)
+	iqr := quantileSorted(sorted, 0.75) - quantileSorted(sorted, 0.25)
+	width := 2 * iqr / math.Cbrt(float64(len(input)))
+	return binsOfWidth(input, width)
+}
+
+func squareRoot(input []float64) int {
+	return int(math.Ceil(math.Sqrt(float64(len(input)))))
+}
+
+func doane(input []float64) int {
+	input = finite(input)
+	n := float64(len(input))
+	sd := stddev(input)
+	if n < 3 || sd == 0 {
+		return sturges(input)
+	}
+	m := mean(input)
+	var m3 float64
+	for _, v := range input {
+		m3 += math.Pow(v-m, 3)
+	}
+	skew := m3 / n / math.Pow(sd, 3)
+	sigmaSkew := math.Sqrt(6 * (n - 2) / ((n + 1) * (n + 3)))
+	return int(math.Ceil(1 + math.Log2(n) + math.Log2(1+math.Abs(skew)/sigmaSkew)))
+}
+
+// binsOfWidth gives how many buckets of width are needed to cover
+// input, falling back on Sturges when width is degenerate. It gives
+// no more than MaxAutoBins buckets.
+func binsOfWidth(input []float64, width float64) int {
+	if len(input) == 0 {
+		return 1
+	}
+	min, max := minmax(input)
+	if !(width > 0) || math.IsInf(width, 1) || min == max {
+		return sturges(input)
+	}
+	return int(math.Ceil(math.Min((max-min)/width, MaxAutoBins)))
+}
+
+// HistAuto creates an histogram partitionning input over a number
+// of buckets picked by rule, up to MaxAutoBins. The bucket edges are
+// rounded to nice values, multiples of 1, 2, 2.5 or 5×10^k, so the
+// histogram may have a few more or less buckets than what rule
+// picked.
+//
+// The rule and the edges only account for the finite values of
+// input. NaN values are counted apart, and infinite values are
+// counted in underflow and overflow buckets. Values too far from 0
+// for their spread to be split in round widths, such as nanosecond
+// timestamps, are partitionned like Hist does, and their infinite
+// values are counted apart.
+func HistAuto(rule BinRule, input []float64) Histogram {
+	values := finite(input)
+	if len(values) == 0 {
+		h := Histogram{}
+		h.NaN, h.Inf = countNonFinite(input)
+		return h
+	}
+	min, max := minmax(values)
+	if min == max {
+		h := Hist(1, values)
+		h.NaN, h.Inf = countNonFinite(input)
+		return h
+	}
+	bins := imin(imax(1, rule(values)), MaxAutoBins)
+	edges := niceEdges(bins, min, max)
+	if edges == nil {
+		h := Hist(bins, values)
+		h.NaN, h.Inf = countNonFinite(input)
+		return h
+	}
+	return HistEdges(edges, input)
+}
+
+// finite gives the values of input that are neither NaN nor infinite.
+func finite(input []float64) []float64 {
+	values := make([]float64, 0, len(input))
+	for _, v := range input {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// countNonFinite counts the NaN and the infinite values of input.
+func countNonFinite(input []float64) (nan, inf int) {
+	for _, v := range input {
+		switch {
+		case math.IsNaN(v):
+			nan++
+		case math.IsInf(v, 0):
+			inf++
+		}
+	}
+	return nan, inf
+}
+
+// niceEdges gives the edges of about `bins` buckets covering
+// [min, max], with a round width. It gives nil when min and max are so
+// far from 0 that multiples of the width can't be told apart.
+func niceEdges(bins int, min, max float64) []float64 {
+	step := niceStep((max - min) / float64(bins))
+	from, to := math.Floor(min/step), math.Ceil(max/step)
+	// beyond 2^52, floats don't hold every integer count of steps
+	if math.Max(math.Abs(from), math.Abs(to)) >= 1<<52 {
+		return nil
+	}
+	if from*step > min {
+		from--
+	}
+	if to*step < max {
+		to++
+	}
+	edges := make([]float64, 0, int(to-from)+1)
+	for i := 0; i <= int(to-from); i++ {
+		// rounding may give a multiple of step the value of the
+		// previous one
+		if e := (from + float64(i)) * step; len(edges) == 0 || e > edges[len(edges)-1] {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// niceStep rounds up step to the closest multiple of 1, 2, 2.5 or 5
+// times a power of 10.
+func niceStep(step float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(step)))
+	for _, nice := range []float64{1, 2, 2.5, 5} {
+		if nice*magnitude >= step {
+			return nice * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// trimOpen drops the underflow and overflow buckets if they're empty.
+func trimOpen(buckets []Bucket) []Bucket {
+	if n := len(buckets); n > 0 && buckets[n-1].Count == 0 && math.IsInf(buckets[n-1].Max, 1) {
+		buckets = buckets[:n-1]
+	}
+	if len(buckets) > 0 && buckets[0].Count == 0 && math.IsInf(buckets[0].Min, -1) {
+		buckets = buckets[1:]
+	}
+	return buckets
+}
+
+func minmax(input []float64) (min, max float64) {
+	min, max = input[0], input[0]
+	for _, val := range input {
+		min = math.Min(min, val)
+		max = math.Max(max, val)
+	}
+	return min, max
+}
+
+func mean(input []float64) float64 {
+	var sum float64
+	for _, v := range input {
+		sum += v
+	}
+	return sum / float64(len(input))
+}
+
+func stddev(input []float64) float64 {
+	m := mean(input)
+	var sum float64
+	for _, v := range input {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(input)))
+}
+
+func sortedCopy(input []float64) []float64 {
+	sorted := append([]float64(nil), input...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// quantileSorted gives the q-th quantile of sorted values,
+// interpolating between the closest ranks.
+func quantileSorted(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := imin(lo+1, len(sorted)-1)
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
@@ -0,0 +1,130 @@
+package histogram
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+func ExampleHistAuto() {
+	data := []float64{
+		float64(time.Millisecond * 103),
+		float64(time.Millisecond * 200),
+		float64(time.Millisecond * 210),
+		float64(time.Millisecond * 220),
+		float64(time.Millisecond * 221),
+		float64(time.Millisecond * 222),
+		float64(time.Millisecond * 223),
+		float64(time.Millisecond * 300),
+		float64(time.Millisecond * 400),
+		float64(time.Millisecond * 500),
+		float64(time.Millisecond * 510),
+		float64(time.Millisecond * 520),
+		float64(time.Millisecond * 530),
+		float64(time.Millisecond * 540),
+		float64(time.Millisecond * 550),
+		float64(time.Millisecond * 560),
+		float64(time.Millisecond * 570),
+		float64(time.Millisecond * 580),
+		float64(time.Millisecond * 600),
+		float64(time.Millisecond * 800),
+		float64(time.Millisecond * 900),
+		float64(time.Millisecond * 1037),
+	}
+
+	hist := HistAuto(Sturges, data)
+
+	err := Fprintf(os.Stdout, hist, Linear(5), func(v float64) string {
+		return time.Duration(v).String()
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0s-200ms     4.55%  ▋       1
+	// 200ms-400ms  31.8%  ███▋    7
+	// 400ms-600ms  45.5%  █████▏  10
+	// 600ms-800ms  4.55%  ▋       1
+	// 800ms-1s     9.09%  █▏      2
+	// 1s-1.2s      4.55%  ▋       1
+}
+
+func TestHistAutoOutlier(t *testing.T) {
+	data := make([]float64, 0, 1001)
+	for i := 0; i < 1000; i++ {
+		data = append(data, float64(i)/1000)
+	}
+	data = append(data, 1e9)
+
+	h := HistAuto(FreedmanDiaconis, data)
+	if n := len(h.Buckets); n > MaxAutoBins+1 {
+		t.Fatalf("want at most %d buckets, got %d", MaxAutoBins+1, n)
+	}
+	if h.Count != 1001 {
+		t.Fatalf("want a count of 1001, got %v", h.Count)
+	}
+}
+
+func TestHistAutoNarrowIQR(t *testing.T) {
+	for _, spread := range []float64{0, 1e-12} {
+		data := make([]float64, 0, 1001)
+		for i := 0; i < 1000; i++ {
+			data = append(data, 1+float64(i)*spread)
+		}
+		data = append(data, 2)
+
+		for _, rule := range []BinRule{FreedmanDiaconis, Scott} {
+			h := HistAuto(rule, data)
+			if n := len(h.Buckets); n == 0 || n > MaxAutoBins+1 {
+				t.Fatalf("spread %v: want 1 to %d buckets, got %d", spread, MaxAutoBins+1, n)
+			}
+		}
+	}
+}
+
+func TestHistAutoNonFinite(t *testing.T) {
+	data := []float64{1, 2, 2, 3, 3, 3, 4, math.NaN(), math.Inf(1)}
+	for _, rule := range []BinRule{Sturges, Scott, FreedmanDiaconis, SquareRoot, Doane} {
+		h := HistAuto(rule, data)
+		if h.NaN != 1 || h.Count != 8 {
+			t.Fatalf("want 1 NaN and 8 counted values, got %d and %v", h.NaN, h.Count)
+		}
+		if last := h.Buckets[len(h.Buckets)-1]; !math.IsInf(last.Max, 1) || last.Count != 1 {
+			t.Fatalf("want +Inf in the overflow bucket, got %+v", last)
+		}
+	}
+
+	h := HistAuto(FreedmanDiaconis, []float64{math.NaN(), math.Inf(-1)})
+	if len(h.Buckets) != 0 || h.NaN != 1 || h.Inf != 1 {
+		t.Fatalf("want no bucket, 1 NaN and 1 Inf, got %+v", h)
+	}
+}
+
+func TestBinRuleDegenerateWidth(t *testing.T) {
+	// the IQR of these values is 0
+	data := []float64{1, 1, 1, 1, 2, math.NaN(), math.Inf(1)}
+	if got, want := FreedmanDiaconis(data), sturges([]float64{1, 1, 1, 1, 2}); got != want {
+		t.Fatalf("want the Sturges fallback of %d buckets, got %d", want, got)
+	}
+}
+
+func TestHistAutoFarFromZero(t *testing.T) {
+	for _, base := range []float64{1e20, 1.7e18, -1e20} {
+		data := make([]float64, 100)
+		for i := range data {
+			data[i] = base + float64(i)*1000
+		}
+		for _, rule := range []BinRule{Sturges, SquareRoot, FreedmanDiaconis} {
+			h := HistAuto(rule, data)
+			if h.Count != 100 || len(h.Buckets) > MaxAutoBins+2 {
+				t.Fatalf("%g: want 100 values in at most %d buckets, got %v in %d", base, MaxAutoBins+2, h.Count, len(h.Buckets))
+			}
+			for i := 1; i < len(h.Buckets); i++ {
+				if h.Buckets[i].Min < h.Buckets[i-1].Min {
+					t.Fatalf("%g: buckets %d and %d are out of order: %+v", base, i-1, i, h.Buckets)
+				}
+			}
+		}
+	}
+}
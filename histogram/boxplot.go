@@ -0,0 +1,178 @@
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Group is a named set of values, such as the latencies of an
+// endpoint, drawn on a line of its own by FprintBoxPlot and
+// FprintViolin.
+type Group struct {
+	Name   string
+	Values []float64
+}
+
+// FprintBoxPlot prints a box-and-whisker plot of each group on a line,
+// all groups sharing an axis `width` columns wide:
+//
+//	/login         ├──[▓▓|▓▓]──────┤                ·
+//	/search                 ├─────[▓▓|▓▓▓]───┤
+//	/health  ├|┤
+//	         12ms 19ms 27ms 34ms 42ms 49ms 57ms 70ms
+//
+// The box spans the first to the third quartile of a group, with a
+// bar at its median. The whiskers reach the furthest values within
+// 1.5 times the interquartile range of the box, and values further
+// away are drawn as outlier dots. NaN values are ignored.
+func FprintBoxPlot(w io.Writer, width int, f FormatFunc, groups ...Group) error {
+	sorted, min, max := sortGroups(groups)
+	if width < 2 || len(sorted) == 0 {
+		return nil
+	}
+	col := func(v float64) int {
+		return int(math.Round((v - min) / (max - min) * float64(width-1)))
+	}
+
+	buf := bytes.NewBuffer(nil)
+	nameWidth := groupNameWidth(groups)
+	for i, g := range groups {
+		line := []rune(strings.Repeat(" ", width))
+		if vals := sorted[i]; len(vals) > 0 {
+			drawBox(line, vals, col)
+		}
+		fmt.Fprintf(buf, "%s%s\n", padName(g.Name, nameWidth), string(line))
+	}
+	// edge i of these buckets sits at column i
+	axis := Histogram{Buckets: linearBuckets(width-1, min, max)}
+	fmt.Fprintf(buf, "%*s%s\n", nameWidth, "", xlabels(axis, f, 1))
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// drawBox draws the box and whiskers of the sorted values on line,
+// placing values on the columns given by col.
+func drawBox(line []rune, sorted []float64, col func(float64) int) {
+	q1 := quantileSorted(sorted, 0.25)
+	median := quantileSorted(sorted, 0.5)
+	q3 := quantileSorted(sorted, 0.75)
+	fence := 1.5 * (q3 - q1)
+
+	lo, hi := q1, q3
+	for _, v := range sorted {
+		if v < q1-fence || v > q3+fence {
+			line[col(v)] = '·'
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	for c := col(lo); c <= col(hi); c++ {
+		line[c] = '─'
+	}
+	line[col(lo)] = '├'
+	line[col(hi)] = '┤'
+	for c := col(q1); c <= col(q3); c++ {
+		line[c] = '▓'
+	}
+	line[col(q1)] = '['
+	line[col(q3)] = ']'
+	line[col(median)] = '|'
+}
+
+// violinGlyphs are vertically centered glyphs of increasing weight.
+var violinGlyphs = []string{" ", "·", "-", "=", "≡", "█"}
+
+// FprintViolin prints a compact violin plot of each group on a line,
+// all groups sharing an axis `width` columns wide:
+//
+//	cache hit   ···=███≡-···
+//	cache miss          ·  ·==≡█≡≡█-········-·------····
+//	            9ms 16ms 26ms 36ms 46ms 55ms 65ms   87ms
+//
+// Each column is a bucket of an histogram of the group, drawn with a
+// glyph as heavy as the bucket holds values relative to the fullest
+// bucket of the group. NaN values are ignored.
+func FprintViolin(w io.Writer, width int, f FormatFunc, groups ...Group) error {
+	sorted, min, max := sortGroups(groups)
+	if width < 1 || len(sorted) == 0 {
+		return nil
+	}
+	// values are put in columns by their offset from min, rather than
+	// by edges, which can't be told apart on a range narrow relative
+	// to its values
+	column := func(v float64) int {
+		return imin(int((v-min)/(max-min)*float64(width)), width-1)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	nameWidth := groupNameWidth(groups)
+	for i, g := range groups {
+		counts := make([]float64, width)
+		peak := 0.0
+		for _, v := range sorted[i] {
+			c := column(v)
+			counts[c]++
+			peak = math.Max(peak, counts[c])
+		}
+		var line strings.Builder
+		for _, count := range counts {
+			level := 0
+			if peak > 0 {
+				level = int(math.Ceil(count / peak * float64(len(violinGlyphs)-1)))
+			}
+			line.WriteString(violinGlyphs[level])
+		}
+		fmt.Fprintf(buf, "%s%s\n", padName(g.Name, nameWidth), line.String())
+	}
+	axis := Histogram{Buckets: linearBuckets(width, min, max)}
+	fmt.Fprintf(buf, "%*s%s\n", nameWidth, "", xlabels(axis, f, 1))
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// sortGroups gives the sorted finite values of each group, and the
+// bounds of the axis holding all of them. It gives no values if no
+// group has any.
+func sortGroups(groups []Group) (sorted [][]float64, min, max float64) {
+	sorted = make([][]float64, len(groups))
+	min, max = math.Inf(1), math.Inf(-1)
+	for i, g := range groups {
+		vals := make([]float64, 0, len(g.Values))
+		for _, v := range g.Values {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				vals = append(vals, v)
+			}
+		}
+		sorted[i] = sortedCopy(vals)
+		if len(vals) > 0 {
+			min = math.Min(min, sorted[i][0])
+			max = math.Max(max, sorted[i][len(vals)-1])
+		}
+	}
+	if min > max {
+		return nil, 0, 0
+	}
+	if min == max {
+		// far from 0, half a unit is lost in rounding
+		pad := math.Max(0.5, math.Abs(min)*1e-9)
+		min, max = min-pad, max+pad
+	}
+	return sorted, min, max
+}
+
+// groupNameWidth gives the width of the column of group names.
+func groupNameWidth(groups []Group) int {
+	width := 0
+	for _, g := range groups {
+		width = imax(width, utf8.RuneCountInString(g.Name))
+	}
+	return width + 2
+}
+
+func padName(name string, width int) string {
+	return name + strings.Repeat(" ", width-utf8.RuneCountInString(name))
+}
@@ -0,0 +1,76 @@
+package histogram
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+var endpoints = []Group{
+	{Name: "/login", Values: []float64{21, 23, 25, 26, 27, 28, 30, 31, 33, 35, 38, 44, 70}},
+	{Name: "/search", Values: []float64{35, 40, 42, 44, 45, 47, 48, 50, 53, 55, 58, 60}},
+	{Name: "/health", Values: []float64{12, 13, 13, 14, 15}},
+}
+
+func formatMillis(v float64) string { return fmt.Sprintf("%.0fms", v) }
+
+func ExampleFprintBoxPlot() {
+	if err := FprintBoxPlot(os.Stdout, 40, formatMillis, endpoints...); err != nil {
+		panic(err)
+	}
+	// Output:
+	// /login         ├──[▓▓|▓▓]──────┤                ·
+	// /search                 ├─────[▓▓|▓▓▓]───┤
+	// /health  ├|┤
+	//          12ms 19ms 27ms 34ms 42ms 49ms 57ms 70ms
+}
+
+func ExampleFprintViolin() {
+	r := rand.New(rand.NewSource(42))
+	var fast, bimodal Group
+	fast.Name, bimodal.Name = "cache hit", "cache miss"
+	for i := 0; i < 500; i++ {
+		fast.Values = append(fast.Values, 20+4*r.NormFloat64())
+		if i%3 == 0 {
+			bimodal.Values = append(bimodal.Values, 70+6*r.NormFloat64())
+		} else {
+			bimodal.Values = append(bimodal.Values, 40+5*r.NormFloat64())
+		}
+	}
+	if err := FprintViolin(os.Stdout, 40, formatMillis, fast, bimodal); err != nil {
+		panic(err)
+	}
+	// Output:
+	// cache hit   ···=███≡-···
+	// cache miss          ·  ·==≡█≡≡█-········-·------····
+	//             9ms 16ms 26ms 36ms 46ms 55ms 65ms   87ms
+}
+
+func ExampleFprintViolin_max() {
+	g := Group{Name: "g", Values: []float64{0.2, 0.5, 0.9, 0.9}}
+	f := func(v float64) string { return fmt.Sprintf("%.1f", v) }
+	if err := FprintViolin(os.Stdout, 7, f, g); err != nil {
+		panic(err)
+	}
+	// Output:
+	// g  =  =  █
+	//    0.2 0.9
+}
+
+func TestBoxPlotFarFromZero(t *testing.T) {
+	for _, values := range [][]float64{
+		{1.7e18},
+		{1.7e18, 1.7e18 + 256, 1.7e18 + 512},
+		{-1e20, -1e20 + 16384},
+	} {
+		g := Group{Name: "g", Values: values}
+		if err := FprintBoxPlot(io.Discard, 20, defaultFormat, g); err != nil {
+			t.Fatal(err)
+		}
+		if err := FprintViolin(io.Discard, 20, defaultFormat, g); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package histogram
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// codecVersion is the version of the JSON, CSV and binary encodings
+// of an Histogram. Decoders reject encodings of a newer version.
+const codecVersion = 1
+
+var (
+	// ErrUnsupportedVersion is returned when decoding an histogram
+	// encoded by a newer version of this package.
+	ErrUnsupportedVersion = errors.New("histogram: unsupported encoding version")
+	// ErrInvalidEncoding is returned when decoding data that isn't
+	// an encoded histogram.
+	ErrInvalidEncoding = errors.New("histogram: invalid encoding")
+)
+
+// checkVersion tells whether encodings of version v can be decoded.
+// Versions start at 1, so a missing version is invalid.
+func checkVersion(v int) error {
+	switch {
+	case v < 1:
+		return ErrInvalidEncoding
+	case v > codecVersion:
+		return ErrUnsupportedVersion
+	}
+	return nil
+}
+
+// csvHeader names the columns of the CSV encoding.
+var csvHeader = []string{"min", "max", "count"}
+
+// The Min, Max and Count of an Histogram are tallied from its buckets
+// when it's decoded, so they aren't encoded.
+
+type jsonHistogram struct {
+	Version  int          `json:"version"`
+	Sum      jsonFloat    `json:"sum"`
+	NaN      int          `json:"nan,omitempty"`
+	Inf      int          `json:"inf,omitempty"`
+	HalfOpen bool         `json:"half_open,omitempty"`
+	Buckets  []jsonBucket `json:"buckets"`
+}
+
+type jsonBucket struct {
+	Min   jsonFloat `json:"min"`
+	Max   jsonFloat `json:"max"`
+	Count float64   `json:"count"`
+}
+
+// jsonFloat encodes infinite and NaN values as "+Inf", "-Inf" and
+// "NaN" strings, which JSON numbers can't represent.
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return []byte(strconv.Quote(formatValue(v))), nil
+	}
+	return json.Marshal(v)
+}
+
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return ErrInvalidEncoding
+		}
+		*f = jsonFloat(v)
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = jsonFloat(v)
+	return nil
+}
+
+// MarshalJSON encodes the buckets of h with its sum and its NaN and
+// infinite values. Infinite bounds are encoded as "-Inf" and "+Inf".
+// Histograms whose last bucket excludes its high bound, like those of
+// PowerHist, are marked "half_open".
+func (h Histogram) MarshalJSON() ([]byte, error) {
+	jh := jsonHistogram{
+		Version:  codecVersion,
+		Sum:      jsonFloat(h.Sum),
+		NaN:      h.NaN,
+		Inf:      h.Inf,
+		HalfOpen: h.halfOpen,
+		Buckets:  make([]jsonBucket, len(h.Buckets)),
+	}
+	for i, bkt := range h.Buckets {
+		jh.Buckets[i] = jsonBucket{Min: jsonFloat(bkt.Min), Max: jsonFloat(bkt.Max), Count: bkt.Count}
+	}
+	return json.Marshal(jh)
+}
+
+// UnmarshalJSON decodes an histogram encoded by MarshalJSON.
+func (h *Histogram) UnmarshalJSON(data []byte) error {
+	var jh jsonHistogram
+	if err := json.Unmarshal(data, &jh); err != nil {
+		return err
+	}
+	if err := checkVersion(jh.Version); err != nil {
+		return err
+	}
+	buckets := make([]Bucket, len(jh.Buckets))
+	for i, bkt := range jh.Buckets {
+		buckets[i] = Bucket{Count: bkt.Count, Min: float64(bkt.Min), Max: float64(bkt.Max)}
+	}
+	*h = decoded(buckets, float64(jh.Sum), jh.NaN, jh.Inf, jh.HalfOpen)
+	return nil
+}
+
+// decoded rebuilds an histogram out of its encoded parts.
+func decoded(buckets []Bucket, sum float64, nan, inf int, halfOpen bool) Histogram {
+	h := newHistogram(buckets)
+	h.Sum = sum
+	h.NaN = nan
+	h.Inf = inf
+	h.halfOpen = halfOpen
+	return h
+}
+
+// WriteCSV writes h on w as CSV, with a `min,max,count` row per
+// bucket. The rows are preceded by a comment line holding the version
+// of the encoding, the sum and the NaN and infinite values of h, and
+// whether its last bucket excludes its high bound:
+//
+//	# histogram v1 sum=2.8 nan=0 inf=0 half_open=false
+//	min,max,count
+//	0.1,0.2,3
+//	0.2,0.4,2
+func WriteCSV(w io.Writer, h Histogram) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# histogram v%d sum=%s nan=%d inf=%d half_open=%t\n", codecVersion, formatValue(h.Sum), h.NaN, h.Inf, h.halfOpen)
+	csvw := csv.NewWriter(bw)
+	csvw.Write(csvHeader)
+	for _, bkt := range h.Buckets {
+		csvw.Write([]string{formatValue(bkt.Min), formatValue(bkt.Max), formatValue(bkt.Count)})
+	}
+	csvw.Flush()
+	if err := csvw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads an histogram written by WriteCSV.
+func ReadCSV(r io.Reader) (Histogram, error) {
+	br := bufio.NewReader(r)
+	comment, err := br.ReadString('\n')
+	if err != nil {
+		return Histogram{}, ErrInvalidEncoding
+	}
+	var (
+		version, nan, inf int
+		sum               string
+		halfOpen          bool
+	)
+	if _, err := fmt.Sscanf(strings.TrimSpace(comment), "# histogram v%d sum=%s nan=%d inf=%d half_open=%t", &version, &sum, &nan, &inf, &halfOpen); err != nil {
+		return Histogram{}, ErrInvalidEncoding
+	}
+	if err := checkVersion(version); err != nil {
+		return Histogram{}, err
+	}
+	total, err := strconv.ParseFloat(sum, 64)
+	if err != nil {
+		return Histogram{}, ErrInvalidEncoding
+	}
+
+	csvr := csv.NewReader(br)
+	csvr.FieldsPerRecord = 3
+	records, err := csvr.ReadAll()
+	if err != nil {
+		return Histogram{}, err
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return Histogram{}, ErrInvalidEncoding
+	}
+	buckets := make([]Bucket, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		var vals [3]float64
+		for i, field := range rec {
+			if vals[i], err = strconv.ParseFloat(field, 64); err != nil {
+				return Histogram{}, ErrInvalidEncoding
+			}
+		}
+		buckets = append(buckets, Bucket{Min: vals[0], Max: vals[1], Count: vals[2]})
+	}
+	return decoded(buckets, total, nan, inf, halfOpen), nil
+}
+
+// binaryMagic starts the binary encoding of an Histogram.
+const binaryMagic = "UPH"
+
+// MarshalBinary encodes h in a compact binary form: a magic header
+// and a version byte, followed by a byte set to 1 if the last bucket
+// excludes its high bound, the sum, the NaN and infinite values, and
+// the bounds and count of every bucket.
+func (h Histogram) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(codecVersion)
+	if h.halfOpen {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+
+	var scratch [binary.MaxVarintLen64]byte
+	putFloat := func(v float64) {
+		binary.LittleEndian.PutUint64(scratch[:8], math.Float64bits(v))
+		buf.Write(scratch[:8])
+	}
+	putUvarint := func(v int) {
+		buf.Write(scratch[:binary.PutUvarint(scratch[:], uint64(v))])
+	}
+
+	putFloat(h.Sum)
+	putUvarint(h.NaN)
+	putUvarint(h.Inf)
+	putUvarint(len(h.Buckets))
+	for _, bkt := range h.Buckets {
+		putFloat(bkt.Min)
+		putFloat(bkt.Max)
+		putFloat(bkt.Count)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes an histogram encoded by MarshalBinary.
+func (h *Histogram) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidEncoding
+	}
+	if err := checkVersion(int(data[len(binaryMagic)])); err != nil {
+		return err
+	}
+	r := bytes.NewReader(data[len(binaryMagic)+1:])
+	halfOpen, err := r.ReadByte()
+	if err != nil || halfOpen > 1 {
+		return ErrInvalidEncoding
+	}
+
+	var bad bool
+	getFloat := func() float64 {
+		var bits uint64
+		if err := binary.Read(r, binary.LittleEndian, &bits); err != nil {
+			bad = true
+		}
+		return math.Float64frombits(bits)
+	}
+	getUvarint := func() int {
+		v, err := binary.ReadUvarint(r)
+		if err != nil || v > math.MaxInt32 {
+			bad = true
+		}
+		return int(v)
+	}
+
+	sum := getFloat()
+	nan := getUvarint()
+	inf := getUvarint()
+	n := getUvarint()
+	if bad || n > r.Len()/24 {
+		return ErrInvalidEncoding
+	}
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i] = Bucket{Min: getFloat(), Max: getFloat(), Count: getFloat()}
+	}
+	if bad || r.Len() > 0 {
+		return ErrInvalidEncoding
+	}
+	*h = decoded(buckets, sum, nan, inf, halfOpen == 1)
+	return nil
+}
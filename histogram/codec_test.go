@@ -0,0 +1,92 @@
+package histogram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func ExampleHistogram_MarshalJSON() {
+	h := HistEdges([]float64{0, 1, 2}, []float64{0.5, 1.5, 1.7, 3})
+	data, err := json.Marshal(h)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+
+	var decoded Histogram
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		panic(err)
+	}
+	if err := Fprint(os.Stdout, decoded, Linear(4)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// {"version":1,"sum":6.7,"buckets":[{"min":0,"max":1,"count":1},{"min":1,"max":2,"count":2},{"min":2,"max":"+Inf","count":1}]}
+	// 0-1  25%  ██▏    1
+	// 1-2  50%  ████▏  2
+	// >2   25%  ██▏    1
+}
+
+func ExampleWriteCSV() {
+	h := Hist(2, []float64{0, 0.5, 1, 1})
+	if err := WriteCSV(os.Stdout, h); err != nil {
+		panic(err)
+	}
+	// Output:
+	// # histogram v1 sum=2.5 nan=0 inf=0 half_open=false
+	// min,max,count
+	// 0,0.5,1
+	// 0.5,1,3
+}
+
+func ExampleHistogram_MarshalBinary() {
+	rec := NewRecorder(LinearLayout(4, 0, 1))
+	for _, v := range []float64{0.1, 0.3, 0.35, 0.9} {
+		rec.Record(v)
+	}
+	data, err := rec.Snapshot().MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(len(data), "bytes")
+
+	var h Histogram
+	if err := h.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, h); err != nil {
+		panic(err)
+	}
+	back, err := ReadCSV(&buf)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(back.Count, back.Sum)
+	// Output:
+	// 160 bytes
+	// 4 1.65
+}
+
+func ExampleReadCSV_invalid() {
+	for _, data := range []string{
+		"# histogram v0 sum=1 nan=0 inf=0 half_open=false\nmin,max,count\n0,1,1\n",
+		"# histogram v1 sum=1 nan=0 inf=0 half_open=false\n0,1,1\n",
+		"# histogram v2 sum=1 nan=0 inf=0 half_open=false\nmin,max,count\n0,1,1\n",
+	} {
+		_, err := ReadCSV(strings.NewReader(data))
+		fmt.Println(err)
+	}
+	var h Histogram
+	fmt.Println(json.Unmarshal([]byte(`{"sum":1,"buckets":[{"min":0,"max":1,"count":1}]}`), &h))
+	fmt.Println(h.UnmarshalBinary([]byte("UPH\x00")))
+	// Output:
+	// histogram: invalid encoding
+	// histogram: invalid encoding
+	// histogram: unsupported encoding version
+	// histogram: invalid encoding
+	// histogram: invalid encoding
+}
@@ -0,0 +1,93 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// timeouts are mostly fast requests, and a few that hit a timeout
+var timeouts = []float64{
+	0.11, 0.12, 0.12, 0.13, 0.15, 0.15, 0.16, 0.18, 0.2, 0.21, 0.25,
+	1.9, 2, 2, 2,
+}
+
+func ExampleCollapseEmpty() {
+	h := Hist(20, timeouts)
+	f := func(v float64) string {
+		return time.Duration(v * float64(time.Second)).Round(time.Millisecond).String()
+	}
+	if err := Fprintf(os.Stdout, h, Linear(5), f, CollapseEmpty(3)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 110ms-205ms    60%    █████▏  9
+	// 205ms-299ms    13.3%  █▏      2
+	// … 16 empty buckets (299ms–1.811s) …
+	// 1.811s-1.906s  6.67%  ▋       1
+	// 1.906s-2s      20%    █▋      3
+}
+
+func ExampleTrimEmpty() {
+	rec := NewRecorder(LinearLayout(10, 0, 100))
+	for _, v := range []float64{42, 45, 51, 58, 59} {
+		rec.Record(v)
+	}
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(5), TrimEmpty()); err != nil {
+		panic(err)
+	}
+	fmt.Println()
+	if err := FprintVertical(os.Stdout, rec.Snapshot(), 3, TrimEmpty()); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 40-50  40%  ███▍    2
+	// 50-60  60%  █████▏  3
+	//
+	// 3 ┤ █
+	//   │██
+	//   │██
+	// 0 └──
+	//    40 60
+}
+
+func ExampleCollapseEmpty_underflow() {
+	rec := NewRecorder(LinearLayout(5, 0, 1))
+	for _, v := range []float64{0.85, 0.9, 0.95} {
+		rec.Record(v)
+	}
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(5), CollapseEmpty(3)); err != nil {
+		panic(err)
+	}
+	fmt.Println()
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(5), CollapseEmpty(3), CumulativePercent()); err != nil {
+		panic(err)
+	}
+	// Output:
+	// … 5 empty buckets (< 0.8) …
+	// 0.8-1  100%  █████▏  3
+	// >1     0%    ▏
+	//
+	// … 5 empty buckets (< 0.8) …
+	// 0.8-1  100%  █████▏  3
+	// >1     100%  █████▏  3
+}
+
+func ExampleCollapseEmpty_blankRows() {
+	h := HistEdges([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8}, []float64{0.5, 2.5, 7.5})
+	err := Render(os.Stdout, h,
+		WithScale(Linear(3)),
+		CollapseEmpty(4),
+		EmptyMarker(""),
+		HideColumns(LabelColumn, PercentColumn, CountColumn),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// ███▏
+	//
+	// ███▏
+	// … 4 empty buckets (3–7) …
+	// ███▏
+}
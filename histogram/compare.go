@@ -0,0 +1,182 @@
+package histogram
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+)
+
+// CompareMode is how FprintCompare draws histograms against each
+// other.
+type CompareMode int
+
+const (
+	// Mirrored draws the share of values of the first histogram as
+	// bars growing left of a center axis, and those of the other
+	// histogram as bars growing right of it.
+	Mirrored CompareMode = iota
+	// Delta draws the change of share of values from the first
+	// histogram to the others as bars growing left, for a loss, or
+	// right, for a gain.
+	Delta
+)
+
+var (
+	// ErrTooFewHistograms is returned when comparing less than two
+	// histograms.
+	ErrTooFewHistograms = errors.New("histogram: need at least two histograms to compare")
+	// ErrNoRange is returned when comparing histograms whose buckets
+	// have less than two distinct finite edges between them, such as
+	// empty histograms or histograms of a single value.
+	ErrNoRange = errors.New("histogram: histograms span no range to compare over")
+)
+
+// FprintCompare prints how the histograms hs differ from the first of
+// them, such as the latency distributions before and after a change.
+// The histograms are rebinned onto the union of their edges, and the
+// shares of values in each bucket are drawn over `width` columns
+// according to mode, along with their percent change:
+//
+//	0-2         █│▏       -100%
+//	2-4    ██████│██▏     -66.7%
+//	4-6      ████│█████▏  +25%
+//	6-8       ███│█████▏  +66.7%
+//	8-10       ██│██▏     +0%
+//	10-12        │██▏     new
+//	KS distance 0.312, median shift +1.9
+//
+// Each of the other histograms gets its own block, followed by the
+// Kolmogorov-Smirnov distance between the two distributions and the
+// shift of the median.
+func FprintCompare(w io.Writer, mode CompareMode, width int, f FormatFunc, hs ...Histogram) error {
+	if len(hs) < 2 {
+		return ErrTooFewHistograms
+	}
+
+	edges := unionEdges(hs...)
+	if len(edges) < 2 {
+		return ErrNoRange
+	}
+	shares := sharedShares(hs, EdgesLayout(edges))
+	buckets := shares[0].Buckets
+
+	buf := bytes.NewBuffer(nil)
+	for n, other := range shares[1:] {
+		if n > 0 {
+			buf.WriteByte('\n')
+		}
+		tabw := tabwriter.NewWriter(buf, 2, 2, 2, byte(' '), 0)
+
+		maxShare := 0.0
+		for i := range buckets {
+			switch mode {
+			case Delta:
+				maxShare = math.Max(maxShare, math.Abs(other.Buckets[i].Count-buckets[i].Count))
+			default:
+				maxShare = math.Max(maxShare, math.Max(buckets[i].Count, other.Buckets[i].Count))
+			}
+		}
+		scale := func(v float64) float64 {
+			if maxShare == 0 {
+				return 0
+			}
+			return v / maxShare * float64(width)
+		}
+
+		for i, bkt := range buckets {
+			before, after := bkt.Count, other.Buckets[i].Count
+			var bars string
+			switch mode {
+			case Delta:
+				bars = deltaBars(scale(after-before), width)
+			default:
+				bars = leftBar(scale(before), width) + "│" + barstring(scale(after))
+			}
+			fmt.Fprintf(tabw, "%s\t%s\t%s\n", label(bkt, f), bars, percentChange(before, after))
+		}
+		if err := tabw.Flush(); err != nil {
+			return err
+		}
+
+		ks, shift := ksDistance(shares[0], other), hs[n+1].Quantile(0.5)-hs[0].Quantile(0.5)
+		sign := "+"
+		if shift < 0 {
+			sign, shift = "-", -shift
+		}
+		fmt.Fprintf(buf, "KS distance %.3g, median shift %s%s\n", ks, sign, f(shift))
+	}
+
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// sharedShares rebins hs onto l, with counts normalized to the share
+// of values in each bucket. The underflow and overflow buckets are
+// kept only if any of hs has values in them.
+func sharedShares(hs []Histogram, l Layout) []Histogram {
+	rebinned := make([][]Bucket, len(hs))
+	var below, above float64
+	for i, h := range hs {
+		rebinned[i] = rebin(h, l)
+		below += rebinned[i][0].Count
+		above += rebinned[i][len(rebinned[i])-1].Count
+	}
+
+	shares := make([]Histogram, len(hs))
+	for i, buckets := range rebinned {
+		if above == 0 {
+			buckets = buckets[:len(buckets)-1]
+		}
+		if below == 0 {
+			buckets = buckets[1:]
+		}
+		for j := range buckets {
+			if hs[i].Count > 0 {
+				buckets[j].Count /= hs[i].Count
+			}
+		}
+		shares[i] = newHistogram(buckets)
+	}
+	return shares
+}
+
+// ksDistance is the biggest difference between the cumulative shares
+// of values of a and b, which have the same buckets.
+func ksDistance(a, b Histogram) float64 {
+	var cumA, cumB, dist float64
+	for i := range a.Buckets {
+		cumA += a.Buckets[i].Count
+		cumB += b.Buckets[i].Count
+		dist = math.Max(dist, math.Abs(cumA-cumB))
+	}
+	return dist
+}
+
+// leftBar draws a bar of length v growing leftward from the right of
+// `width` columns.
+func leftBar(v float64, width int) string {
+	n := imin(int(math.Round(v)), width)
+	return strings.Repeat(" ", width-n) + strings.Repeat("█", n)
+}
+
+// deltaBars draws a bar of length |v| growing leftward from a center
+// axis if v is negative, rightward otherwise.
+func deltaBars(v float64, width int) string {
+	if v < 0 {
+		return leftBar(-v, width) + "│"
+	}
+	return strings.Repeat(" ", width) + "│" + barstring(v)
+}
+
+func percentChange(before, after float64) string {
+	switch {
+	case before == 0 && after == 0:
+		return ""
+	case before == 0:
+		return "new"
+	}
+	return fmt.Sprintf("%+.3g%%", (after-before)/before*100)
+}
@@ -0,0 +1,56 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+)
+
+// compareBefore and compareAfter are histograms of a release and of
+// the next one, to compare
+var (
+	compareEdges  = []float64{0, 2, 4, 6, 8, 10, 12}
+	compareBefore = HistEdges(compareEdges, []float64{
+		1, 2, 2, 3, 3, 3, 3, 4, 4, 5,
+		5, 6, 6, 7, 8, 9,
+	})
+	compareAfter = HistEdges(compareEdges, []float64{
+		2, 3, 4, 4, 5, 5, 5, 6, 6, 6,
+		7, 7, 8, 9, 10, 11,
+	})
+)
+
+func ExampleFprintCompare() {
+	if err := FprintCompare(os.Stdout, Mirrored, 6, defaultFormat, compareBefore, compareAfter); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0-2         █│▏       -100%
+	// 2-4    ██████│██▏     -66.7%
+	// 4-6      ████│█████▏  +25%
+	// 6-8       ███│█████▏  +66.7%
+	// 8-10       ██│██▏     +0%
+	// 10-12        │██▏     new
+	// KS distance 0.312, median shift +1.9
+}
+
+func ExampleFprintCompare_delta() {
+	if err := FprintCompare(os.Stdout, Delta, 6, defaultFormat, compareBefore, compareAfter); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 0-2        ██│      -100%
+	// 2-4    ██████│      -66.7%
+	// 4-6          │█▋    +25%
+	// 6-8          │███▏  +66.7%
+	// 8-10         │▏     +0%
+	// 10-12        │███▏  new
+	// KS distance 0.312, median shift +1.9
+}
+
+func ExampleFprintCompare_noRange() {
+	constant := Hist(4, []float64{3, 3, 3})
+	err := FprintCompare(os.Stdout, Mirrored, 6, defaultFormat, constant, Histogram{})
+	fmt.Println(err)
+	// Output:
+	// histogram: histograms span no range to compare over
+}
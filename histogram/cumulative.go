@@ -0,0 +1,98 @@
+package histogram
+
+import (
+	"io"
+	"math"
+	"sort"
+)
+
+// Cumulative gives an histogram where each bucket counts the values
+// of h up to the high bound of the bucket. Its Count is still the
+// total count of values, so that the share of values below a bucket
+// reads as the count of the bucket over the Count of the histogram.
+func (h Histogram) Cumulative() Histogram {
+	buckets := make([]Bucket, len(h.Buckets))
+	running := 0.0
+	for i, bkt := range h.Buckets {
+		running += bkt.Count
+		bkt.Count = running
+		buckets[i] = bkt
+	}
+	return Histogram{
+		Min:     0,
+		Max:     running,
+		Count:   h.Count,
+		Sum:     h.Sum,
+		NaN:     h.NaN,
+		Inf:     h.Inf,
+		Buckets: buckets,
+		// running counts keep the bounds of the buckets
+		halfOpen: h.halfOpen,
+	}
+}
+
+// ECDF is the empirical cumulative distribution function of a set of
+// values. Unlike a cumulative histogram, it's exact at any point.
+type ECDF struct {
+	sorted []float64
+}
+
+// NewECDF builds the empirical distribution of input. NaN values are
+// ignored.
+func NewECDF(input []float64) ECDF {
+	sorted := make([]float64, 0, len(input))
+	for _, v := range input {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+	return ECDF{sorted: sorted}
+}
+
+// Len is the number of values in the distribution.
+func (e ECDF) Len() int { return len(e.sorted) }
+
+// At gives the share of values lower or equal to x, in [0, 1].
+func (e ECDF) At(x float64) float64 {
+	if len(e.sorted) == 0 {
+		return math.NaN()
+	}
+	return float64(e.countAtMost(x)) / float64(len(e.sorted))
+}
+
+func (e ECDF) countAtMost(x float64) int {
+	return sort.Search(len(e.sorted), func(i int) bool { return e.sorted[i] > x })
+}
+
+// FprintECDF draws e as a step plot, `width` columns wide and `height`
+// rows high, with the share of values on the Y axis. Labels of the X
+// axis are formatted with f.
+//
+// The X axis spans the finite values of e: -Inf values are counted in
+// the first column, and +Inf values in the last one. Nothing is drawn
+// if e holds no finite value.
+func FprintECDF(w io.Writer, e ECDF, width, height int, f FormatFunc) error {
+	first := sort.Search(len(e.sorted), func(i int) bool { return !math.IsInf(e.sorted[i], -1) })
+	last := e.countAtMost(math.MaxFloat64) - 1
+	if first > last || width <= 0 {
+		return nil
+	}
+	min, max := e.sorted[first], e.sorted[last]
+	if min == max {
+		width = 1
+	}
+
+	// each column steps up to the count of values at its high bound
+	buckets := linearBuckets(width, min, max)
+	below := 0
+	for i := range buckets {
+		atMost := e.countAtMost(buckets[i].Max)
+		if i == len(buckets)-1 {
+			atMost = len(e.sorted)
+		}
+		buckets[i].Count = float64(atMost - below)
+		below = atMost
+	}
+	return fprintVertical(w, newHistogram(buckets), height, f, options{cumulative: true})
+}
@@ -0,0 +1,57 @@
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"os"
+)
+
+var latencies = []float64{
+	12, 13, 13, 14, 15, 15, 15, 16, 17, 18,
+	19, 21, 22, 25, 28, 31, 35, 42, 60, 95,
+}
+
+func ExampleCumulativePercent() {
+	hist := Hist(5, latencies)
+
+	if err := Fprint(os.Stdout, hist, Linear(10), CumulativePercent()); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 12-28.6    75%   ███████▋     15
+	// 28.6-45.2  90%   █████████▏   18
+	// 45.2-61.8  95%   █████████▋   19
+	// 61.8-78.4  95%   █████████▋   19
+	// 78.4-95    100%  ██████████▏  20
+}
+
+func ExampleFprintECDF() {
+	ecdf := NewECDF(latencies)
+	fmt.Printf("%.0f%% of values are under 20\n", ecdf.At(20)*100)
+
+	if err := FprintECDF(os.Stdout, ecdf, 24, 4, defaultFormat); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 55% of values are under 20
+	// 100% ┤     ▁▃▃▄▄▄▄▄▆▆▆▆▆▆▆▆▆▆█
+	//      │  ▄▆████████████████████
+	//  50% ┤▃███████████████████████
+	//      │████████████████████████
+	//   0% └────────────────────────
+	//       12 22.38 43.12 63.88  95
+}
+
+func ExampleFprintECDF_infinite() {
+	e := NewECDF([]float64{math.Inf(-1), 1, 2, 3, math.Inf(1)})
+	if err := FprintECDF(os.Stdout, e, 4, 4, defaultFormat); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 100% ┤   █
+	//      │ ▃▃█
+	//  60% ┤▄███
+	//      │████
+	//   0% └────
+	//       1  3
+}
@@ -0,0 +1,192 @@
+package histogram
+
+import (
+	"log"
+	"math"
+	"time"
+)
+
+// DecayOption configures an ExpDecayRecorder or a WindowRecorder.
+type DecayOption func(*decayOptions)
+
+type decayOptions struct {
+	now func() time.Time
+}
+
+func newDecayOptions(opts []DecayOption) decayOptions {
+	o := decayOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithClock makes the recorder tell the time with now instead of
+// time.Now, such as to replay values recorded in the past.
+func WithClock(now func() time.Time) DecayOption {
+	return func(o *decayOptions) { o.now = now }
+}
+
+// ExpDecayRecorder counts values over the buckets of a Layout like a
+// Recorder, but the weight of each value halves every halfLife. Its
+// snapshots favor recent values, which suits long running processes.
+//
+// An ExpDecayRecorder is not safe for concurrent use.
+type ExpDecayRecorder struct {
+	layout   Layout
+	halfLife time.Duration
+	now      func() time.Time
+
+	// values are weighted relative to the landmark, so that old
+	// counts need not be decayed on every record
+	landmark time.Time
+	counts   []float64
+	sum      float64
+	nan      float64
+}
+
+// NewExpDecayRecorder creates an ExpDecayRecorder counting values
+// over l, where values weigh half as much every halfLife, which must
+// be positive.
+func NewExpDecayRecorder(l Layout, halfLife time.Duration, opts ...DecayOption) *ExpDecayRecorder {
+	if halfLife <= 0 {
+		log.Panicf("histogram: invalid decay recorder, halfLife=%v", halfLife)
+	}
+	r := &ExpDecayRecorder{
+		layout:   l,
+		halfLife: halfLife,
+		now:      newDecayOptions(opts).now,
+		counts:   make([]float64, len(l.edges)+1),
+	}
+	r.landmark = r.now()
+	return r
+}
+
+// maxDecayExponent bounds the weights of values, past which the
+// counts are rescaled to a new landmark.
+const maxDecayExponent = 64
+
+// weight gives the weight of a value recorded at t, relative to the
+// landmark.
+func (r *ExpDecayRecorder) weight(t time.Time) float64 {
+	exp := float64(t.Sub(r.landmark)) / float64(r.halfLife)
+	if exp > maxDecayExponent {
+		scale := math.Exp2(-exp)
+		for i := range r.counts {
+			r.counts[i] *= scale
+		}
+		r.sum *= scale
+		r.nan *= scale
+		r.landmark = t
+		exp = 0
+	}
+	return math.Exp2(exp)
+}
+
+// Record counts v in its bucket. NaN values are counted apart.
+func (r *ExpDecayRecorder) Record(v float64) { r.RecordN(v, 1) }
+
+// RecordN counts v in its bucket with weight w, as if v had been
+// recorded w times. NaN values are counted apart, with their weight
+// too. The weight must be finite and non-negative.
+func (r *ExpDecayRecorder) RecordN(v, w float64) {
+	checkWeight(w)
+	weight := r.weight(r.now())
+	if math.IsNaN(v) {
+		r.nan += w * weight
+		return
+	}
+	r.counts[r.layout.index(v)] += w * weight
+	if !math.IsInf(v, 0) {
+		r.sum += v * w * weight
+	}
+}
+
+// Snapshot creates an histogram of the values recorded so far,
+// weighted by how recently they were recorded. The first and last
+// buckets of the histogram are the underflow and overflow buckets.
+func (r *ExpDecayRecorder) Snapshot() Histogram {
+	scale := 1 / r.weight(r.now())
+	buckets := r.layout.buckets()
+	for i, c := range r.counts {
+		buckets[i].Count = c * scale
+	}
+	h := newHistogram(buckets)
+	h.Sum = r.sum * scale
+	h.NaN = int(math.Round(r.nan * scale))
+	return h
+}
+
+// WindowRecorder counts the values recorded over the last window of
+// time. The window is split in slices, each counting its values in a
+// Recorder, and the oldest slice is forgotten as time goes.
+//
+// A WindowRecorder is not safe for concurrent use.
+type WindowRecorder struct {
+	slice time.Duration
+	now   func() time.Time
+
+	origin  time.Time
+	current int64
+	ring    []*Recorder
+}
+
+// NewWindowRecorder creates a WindowRecorder counting values over l,
+// for the last window of time split in `slices` slices. Values are
+// forgotten one slice at a time, so more slices make for a smoother
+// window. There must be at least one slice, and no more slices than
+// nanoseconds in the window.
+func NewWindowRecorder(l Layout, window time.Duration, slices int, opts ...DecayOption) *WindowRecorder {
+	if slices < 1 || window < time.Duration(slices) {
+		log.Panicf("histogram: invalid window recorder, window=%v\tslices=%d", window, slices)
+	}
+	r := &WindowRecorder{
+		slice: window / time.Duration(slices),
+		now:   newDecayOptions(opts).now,
+		ring:  make([]*Recorder, slices),
+	}
+	for i := range r.ring {
+		r.ring[i] = NewRecorder(l)
+	}
+	r.origin = r.now()
+	return r
+}
+
+// advance forgets the slices that went out of the window at t, and
+// gives the recorder of the slice holding t.
+func (r *WindowRecorder) advance(t time.Time) *Recorder {
+	n := int64(len(r.ring))
+	slice := int64(t.Sub(r.origin) / r.slice)
+	if slice-r.current >= n {
+		r.current = slice - n
+	}
+	for ; r.current < slice; r.current++ {
+		r.ring[(r.current+1)%n].Reset()
+	}
+	return r.ring[r.current%n]
+}
+
+// Record counts v in its bucket. NaN values are counted apart.
+func (r *WindowRecorder) Record(v float64) { r.advance(r.now()).Record(v) }
+
+// RecordN counts v in its bucket with weight w, as if v had been
+// recorded w times. NaN values are counted apart, with their weight
+// too. The weight must be finite and non-negative.
+func (r *WindowRecorder) RecordN(v, w float64) { r.advance(r.now()).RecordN(v, w) }
+
+// Snapshot creates an histogram of the values recorded over the
+// window. The first and last buckets of the histogram are the
+// underflow and overflow buckets.
+func (r *WindowRecorder) Snapshot() Histogram {
+	r.advance(r.now())
+	var h Histogram
+	for _, rec := range r.ring {
+		var err error
+		h, err = Merge(h, rec.Snapshot())
+		if err != nil {
+			// all the recorders share a layout
+			panic(err)
+		}
+	}
+	return h
+}
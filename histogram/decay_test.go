@@ -0,0 +1,84 @@
+package histogram
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose time only moves when told to.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time        { return c.t }
+func (c *fakeClock) sleep(d time.Duration) { c.t = c.t.Add(d) }
+
+func ExampleNewExpDecayRecorder() {
+	clock := &fakeClock{t: time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)}
+	rec := NewExpDecayRecorder(LinearLayout(2, 0, 100), time.Minute, WithClock(clock.now))
+
+	// a burst of slow requests, a while ago...
+	for i := 0; i < 100; i++ {
+		rec.Record(80)
+	}
+	clock.sleep(3 * time.Minute)
+	// ...and fast requests since
+	for i := 0; i < 100; i++ {
+		rec.Record(20)
+	}
+
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(8)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0      0%     ▏
+	// 0-50    88.9%  ████████▏  100
+	// 50-100  11.1%  █▏         12.5
+	// >100    0%     ▏
+}
+
+func ExampleNewWindowRecorder() {
+	clock := &fakeClock{t: time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)}
+	rec := NewWindowRecorder(LinearLayout(2, 0, 100), 5*time.Minute, 5, WithClock(clock.now))
+
+	for i := 0; i < 100; i++ {
+		rec.Record(80)
+	}
+	clock.sleep(3 * time.Minute)
+	for i := 0; i < 50; i++ {
+		rec.Record(20)
+	}
+	clock.sleep(3 * time.Minute)
+	for i := 0; i < 50; i++ {
+		rec.Record(20)
+	}
+
+	// the slow requests are more than 5 minutes old
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(8)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0      0%    ▏
+	// 0-50    100%  ████████▏  100
+	// 50-100  0%    ▏
+	// >100    0%    ▏
+}
+
+func TestDecayRecorderInvalid(t *testing.T) {
+	layout := LinearLayout(2, 0, 100)
+	for name, create := range map[string]func(){
+		"zero half-life":      func() { NewExpDecayRecorder(layout, 0) },
+		"negative half-life":  func() { NewExpDecayRecorder(layout, -time.Second) },
+		"zero window":         func() { NewWindowRecorder(layout, 0, 1) },
+		"no slice":            func() { NewWindowRecorder(layout, time.Minute, 0) },
+		"more slices than ns": func() { NewWindowRecorder(layout, 3, 5) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: want a panic", name)
+				}
+			}()
+			create()
+		}()
+	}
+}
@@ -3,6 +3,6 @@ Package histogram is a helper to quickly create histograms from slices
 of float64.
 
 You can use it with any numeric value, given that you convert it back
-and forth to []float64.
+and forth to []float64, or pass slices of any numeric type to Of.
 */
 package histogram
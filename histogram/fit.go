@@ -0,0 +1,76 @@
+package histogram
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aybabtme/uniplot/internal/term"
+)
+
+// FitWidth sizes the bars so that the widest line printed by Fprint or
+// Fprintf spans exactly `width` columns, labels, percents and counts
+// included. The shape of the bars given by the ScaleFunc is kept, only
+// their length changes. Lines whose labels alone are wider than width
+// still overflow.
+func FitWidth(width int) Option {
+	return func(o *options) { o.fitWidth = width }
+}
+
+// FitTerminal sizes the bars like FitWidth, so that the widest line of
+// the histogram spans the terminal it's printed on. When printing
+// elsewhere, such as to a file, the terminal of the standard output is
+// used, or 80 columns if there's none.
+func FitTerminal() Option {
+	return func(o *options) { o.fitTerminal = true }
+}
+
+// lineWidth gives the width lines printed on w must fit in, or 0 if
+// they needn't fit.
+func (o options) lineWidth(w io.Writer) int {
+	if o.fitTerminal {
+		return term.Width(w)
+	}
+	return o.fitWidth
+}
+
+// fitScale scales s so that the widest line printed for h spans width
+// columns.
+func fitScale(h Histogram, s ScaleFunc, f FormatFunc, o options, width int) ScaleFunc {
+	// print h with bars of a single glyph to measure the other
+	// columns; the bars column is aligned, so it widens all the
+	// lines by the same amount
+	probe := o
+	probe.fitWidth, probe.fitTerminal = 0, false
+	probe.legend, probe.summary = "", nil
+	noBars := func(min, max, value float64) float64 { return 0 }
+	buf := bytes.NewBuffer(nil)
+	if err := fprintf(buf, h, noBars, f, probe); err != nil {
+		return s
+	}
+	widest := 0
+	for _, line := range strings.Split(buf.String(), "\n") {
+		// folded runs of empty buckets have no bar to shrink
+		if !strings.HasPrefix(line, "…") {
+			widest = imax(widest, utf8.RuneCountInString(line))
+		}
+	}
+	// glyphs of the longest bar, which is a glyph longer than its size
+	longest := width - widest + 1
+
+	h = o.trim(h)
+	if o.cumulative {
+		h = h.Cumulative()
+	}
+	maxSize := 0.0
+	for i := range h.Buckets {
+		maxSize = math.Max(maxSize, h.Scale(s, i))
+	}
+	if !(maxSize > 0) {
+		return s
+	}
+	k := math.Max(0, float64(longest-1)) / maxSize
+	return func(min, max, value float64) float64 { return s(min, max, value) * k }
+}
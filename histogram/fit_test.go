@@ -0,0 +1,29 @@
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func ExampleFitWidth() {
+	h := Hist(4, []float64{0.1, 0.2, 0.2, 0.3, 0.5, 0.5, 0.5, 0.9, 1})
+	var buf bytes.Buffer
+	if err := Fprint(&buf, h, Linear(1), FitWidth(40)); err != nil {
+		panic(err)
+	}
+	fmt.Print(buf.String())
+
+	widest := 0
+	for _, line := range strings.Split(buf.String(), "\n") {
+		widest = imax(widest, utf8.RuneCountInString(line))
+	}
+	fmt.Println("widest line:", widest)
+	// Output:
+	// 0.1-0.325   44.4%  █████████████████▏  4
+	// 0.325-0.55  33.3%  ████████████▊       3
+	// 0.55-0.775  0%     ▏
+	// 0.775-1     22.2%  ████████▋           2
+	// widest line: 40
+}
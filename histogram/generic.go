@@ -0,0 +1,33 @@
+package histogram
+
+import (
+	"time"
+
+	"github.com/aybabtme/uniplot/internal/numeric"
+)
+
+// Number is the constraint of the values that Of can partition. It
+// includes time.Duration and other types defined on numbers.
+type Number = numeric.Number
+
+// Of creates an histogram partitionning input over `bins` buckets,
+// like Hist, without converting input to a []float64 first. It also
+// gives a FormatFunc suited to print the values of T, see FormatOf:
+//
+//	h, f := Of(10, latencies) // latencies is a []time.Duration
+//	err := Fprintf(os.Stdout, h, Linear(5), f)
+func Of[T Number](bins int, input []T) (Histogram, FormatFunc) {
+	return hist(bins, input, nil), FormatOf[T]()
+}
+
+// FormatOf gives a FormatFunc suited to print values of T. Durations
+// print like `1.2ms`, with 3 significant digits, and other numbers
+// like they do in Fprint.
+func FormatOf[T Number]() FormatFunc {
+	var zero T
+	switch any(zero).(type) {
+	case time.Duration:
+		return numeric.FormatDuration
+	}
+	return defaultFormat
+}
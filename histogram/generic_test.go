@@ -0,0 +1,37 @@
+package histogram
+
+import (
+	"os"
+	"time"
+)
+
+func ExampleOf() {
+	latencies := []time.Duration{
+		1200 * time.Microsecond,
+		1300 * time.Microsecond,
+		1350 * time.Microsecond,
+		2 * time.Millisecond,
+		2100 * time.Microsecond,
+		4 * time.Millisecond,
+	}
+	h, f := Of(4, latencies)
+	if err := Fprintf(os.Stdout, h, Linear(5), f); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1.2ms-1.9ms  50%    █████▏  3
+	// 1.9ms-2.6ms  33.3%  ███▍    2
+	// 2.6ms-3.3ms  0%     ▏
+	// 3.3ms-4ms    16.7%  █▋      1
+}
+
+func ExampleOf_uint32() {
+	sizes := []uint32{512, 1024, 1024, 2048, 4096, 4096, 4096}
+	h, f := Of(2, sizes)
+	if err := Fprintf(os.Stdout, h, Linear(5), f); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 512-2304   57.1%  █████▏  4
+	// 2304-4096  42.9%  ███▊    3
+}
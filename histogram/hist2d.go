@@ -0,0 +1,168 @@
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Histogram2D holds a count of pairs of values partitionned over a
+// grid of cells.
+type Histogram2D struct {
+	// X and Y are the buckets of each axis, their counts are the
+	// totals of their column and row of cells.
+	X, Y []Bucket
+	// Counts holds the count of each cell, indexed by Y then X
+	// bucket.
+	Counts [][]float64
+	// Max is the count of the biggest cell.
+	Max float64
+	// Count is the total count of all cells.
+	Count float64
+	// NaN is the number of pairs with a NaN value, which aren't
+	// counted in any cell.
+	NaN int
+	// Inf is the number of pairs with an infinite value and no NaN
+	// value, which aren't counted in any cell either.
+	Inf int
+}
+
+// Hist2D creates a two-dimensional histogram partitionning the pairs
+// (xs[i], ys[i]) over `xbins` buckets on the X axis and `ybins`
+// buckets on the Y axis. Each axis is bucketed like Hist does. Pairs
+// with a NaN or an infinite value are counted apart from the cells.
+func Hist2D(xbins, ybins int, xs, ys []float64) Histogram2D {
+	if len(xs) != len(ys) {
+		log.Panicf("histogram: got %d xs but %d ys", len(xs), len(ys))
+	}
+	if xbins <= 0 || ybins <= 0 {
+		return Histogram2D{}
+	}
+
+	var nan, inf int
+	var xin, yin []float64
+	for i := range xs {
+		switch {
+		case math.IsNaN(xs[i]) || math.IsNaN(ys[i]):
+			nan++
+		case math.IsInf(xs[i], 0) || math.IsInf(ys[i], 0):
+			inf++
+		default:
+			xin = append(xin, xs[i])
+			yin = append(yin, ys[i])
+		}
+	}
+	if len(xin) == 0 {
+		return Histogram2D{NaN: nan, Inf: inf}
+	}
+
+	xaxis, xindex := axis(xbins, xin)
+	yaxis, yindex := axis(ybins, yin)
+
+	counts := make([][]float64, len(yaxis))
+	for i := range counts {
+		counts[i] = make([]float64, len(xaxis))
+	}
+	h := Histogram2D{X: xaxis, Y: yaxis, Counts: counts, NaN: nan, Inf: inf}
+	for i := range xin {
+		xi, yi := xindex(xin[i]), yindex(yin[i])
+		counts[yi][xi]++
+		h.X[xi].Count++
+		h.Y[yi].Count++
+		h.Count++
+		h.Max = math.Max(h.Max, counts[yi][xi])
+	}
+	return h
+}
+
+// axis creates the buckets of an axis, and the func giving the bucket
+// holding a value.
+func axis(bins int, input []float64) ([]Bucket, func(float64) int) {
+	min, max := minmax(input)
+	if min == max {
+		return []Bucket{{Min: min, Max: max}}, func(float64) int { return 0 }
+	}
+	scale := (max - min) / float64(bins)
+	return linearBuckets(bins, min, max), func(v float64) int {
+		return linearIndex(v, min, scale, bins)
+	}
+}
+
+// Ramp gives the glyph drawing a cell filled at v, in [0, 1].
+type Ramp func(v float64) string
+
+var shades = []string{" ", "░", "▒", "▓", "█"}
+
+// ShadeRamp draws cells with shades of blocks, ` ░▒▓█`.
+func ShadeRamp(v float64) string {
+	if v <= 0 {
+		return shades[0]
+	}
+	return shades[1+imin(int(v*float64(len(shades)-1)), len(shades)-2)]
+}
+
+// GrayRamp draws cells with a gradient of the 256-colour grays of
+// ANSI terminals.
+func GrayRamp(v float64) string {
+	if v <= 0 {
+		return " "
+	}
+	// from 236, the darkest visible gray, to 255, white
+	color := 236 + imin(int(v*20), 19)
+	return fmt.Sprintf("\x1b[38;5;%dm█\x1b[0m", color)
+}
+
+// Fprint2D prints a two-dimensional histogram on the io.Writer as a
+// heatmap, with cells drawn by ramp and the Y buckets increasing
+// upward. Labels of the X and Y axis are formatted with x and y.
+//
+//	8.7ms-11.0ms │          ░░▓▓██
+//	6.4ms-8.7ms  │      ░░▓▓██▓▓██
+//	4.1ms-6.4ms  │  ▒▒▓▓██▓▓▓▓▒▒░░
+//	1.8ms-4.1ms  │▓▓▓▓██▓▓▒▒
+//	-0.5ms-1.8ms │██▓▓░░
+//	             └────────────────
+//	              0kB 24kB    99kB
+func Fprint2D(w io.Writer, h Histogram2D, ramp Ramp, x, y FormatFunc) error {
+	if len(h.X) == 0 || len(h.Y) == 0 {
+		return nil
+	}
+
+	ylabels := make([]string, len(h.Y))
+	ywidth := 0
+	for i, bkt := range h.Y {
+		ylabels[i] = label(bkt, y)
+		ywidth = imax(ywidth, utf8.RuneCountInString(ylabels[i]))
+	}
+	pad := func(s string) string {
+		return s + strings.Repeat(" ", ywidth-utf8.RuneCountInString(s))
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for yi := len(h.Y) - 1; yi >= 0; yi-- {
+		buf.WriteString(pad(ylabels[yi]) + " │")
+		for _, c := range h.Counts[yi] {
+			var v float64
+			if h.Max > 0 {
+				v = c / h.Max
+			}
+			// cells are two columns wide to look roughly square
+			glyph := ramp(v)
+			buf.WriteString(glyph + glyph)
+		}
+		buf.WriteByte('\n')
+	}
+	fmt.Fprintf(buf, "%s └%s\n", pad(""), strings.Repeat("─", 2*len(h.X)))
+	fmt.Fprintf(buf, "%s  %s\n", pad(""), xlabels(Histogram{Buckets: h.X}, x, 2))
+	if h.NaN > 0 {
+		fmt.Fprintf(buf, "NaN: %d\n", h.NaN)
+	}
+	if h.Inf > 0 {
+		fmt.Fprintf(buf, "±Inf: %d\n", h.Inf)
+	}
+	return writeTrimmed(w, buf.Bytes())
+}
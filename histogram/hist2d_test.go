@@ -0,0 +1,51 @@
+package histogram
+
+import (
+	"math"
+	"os"
+	"strconv"
+)
+
+func ExampleFprint2D() {
+	// latency grows with the payload size, give or take
+	var sizes, latencies []float64
+	for i := 0; i < 200; i++ {
+		size := float64(i % 100)
+		sizes = append(sizes, size)
+		latencies = append(latencies, 1+size/12+2*math.Sin(float64(i)))
+	}
+
+	hist := Hist2D(8, 5, sizes, latencies)
+	xfmt := func(v float64) string { return strconv.Itoa(int(v)) + "kB" }
+	yfmt := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) + "ms" }
+
+	if err := Fprint2D(os.Stdout, hist, ShadeRamp, xfmt, yfmt); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 8.7ms-11.0ms │          ░░▓▓██
+	// 6.4ms-8.7ms  │      ░░▓▓██▓▓██
+	// 4.1ms-6.4ms  │  ▒▒▓▓██▓▓▓▓▒▒░░
+	// 1.8ms-4.1ms  │▓▓▓▓██▓▓▒▒
+	// -0.5ms-1.8ms │██▓▓░░
+	//              └────────────────
+	//               0kB 24kB    99kB
+}
+
+func ExampleHist2D_nonFinite() {
+	xs := []float64{1, 2, 3, math.Inf(1), 2, math.NaN()}
+	ys := []float64{1, 2, 3, 2, math.Inf(-1), math.Inf(1)}
+
+	hist := Hist2D(3, 3, xs, ys)
+	if err := Fprint2D(os.Stdout, hist, ShadeRamp, defaultFormat, defaultFormat); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 2.333-3     │    ██
+	// 1.667-2.333 │  ██
+	// 1-1.667     │██
+	//             └──────
+	//              1    3
+	// NaN: 1
+	// ±Inf: 2
+}
@@ -8,19 +8,36 @@ import (
 // Histogram holds a count of values partionned over buckets.
 type Histogram struct {
 	// Min is the size of the smallest bucket.
-	Min int
+	Min float64
 	// Max is the size of the biggest bucket.
-	Max int
-	// Count is the total size of all buckets.
-	Count int
+	Max float64
+	// Count is the total size of all buckets. For weighted
+	// histograms, it's the sum of the weights of the values.
+	Count float64
+	// Sum is the sum of the values, weighted for weighted
+	// histograms. Infinite and NaN values aren't summed.
+	Sum float64
+	// NaN is the number of NaN values, which aren't counted in
+	// any bucket.
+	NaN int
+	// Inf is the number of infinite values that could not be
+	// counted in any bucket.
+	Inf int
 	// Buckets over which values are partionned.
 	Buckets []Bucket
+
+	// halfOpen is set when the last finite bucket excludes its high
+	// bound like the others, as in the histograms of PowerHist. The
+	// last finite bucket of other histograms, such as those of Hist
+	// or of a Layout, includes its high bound.
+	halfOpen bool
 }
 
 // Bucket counts a partion of values.
 type Bucket struct {
-	// Count is the number of values represented in the bucket.
-	Count int
+	// Count is the number of values represented in the bucket,
+	// or the sum of their weights for weighted histograms.
+	Count float64
 	// Min is the low, inclusive bound of the bucket.
 	Min float64
 	// Max is the high, exclusive bound of the bucket. If
@@ -31,92 +48,180 @@ type Bucket struct {
 
 // Hist creates an histogram partionning input over `bins` buckets.
 func Hist(bins int, input []float64) Histogram {
+	return hist(bins, input, nil)
+}
+
+// HistWeighted creates an histogram partionning values over `bins`
+// buckets, where each value counts for its weight. Weights must be
+// finite and non-negative, and there must be one per value.
+func HistWeighted(bins int, values, weights []float64) Histogram {
+	if len(values) != len(weights) {
+		log.Panicf("histogram: got %d values but %d weights", len(values), len(weights))
+	}
+	for _, w := range weights {
+		checkWeight(w)
+	}
+	return hist(bins, values, weights)
+}
+
+// hist partitions input over `bins` buckets, weighting each value by
+// weights, or by 1 if weights is nil.
+func hist[T Number](bins int, input []T, weights []float64) Histogram {
 	if len(input) == 0 || bins == 0 {
 		return Histogram{}
 	}
 
-	min, max := input[0], input[0]
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+
+	min, max := float64(input[0]), float64(input[0])
 	for _, val := range input {
-		min = math.Min(min, val)
-		max = math.Max(max, val)
+		min = math.Min(min, float64(val))
+		max = math.Max(max, float64(val))
 	}
 
 	if min == max {
+		var n float64
+		for i := range input {
+			n += weight(i)
+		}
 		return Histogram{
-			Min:     len(input),
-			Max:     len(input),
-			Count:   len(input),
-			Buckets: []Bucket{{Count: len(input), Min: min, Max: max}},
+			Min:     n,
+			Max:     n,
+			Count:   n,
+			Sum:     n * min,
+			Buckets: []Bucket{{Count: n, Min: min, Max: max}},
 		}
 	}
 
 	scale := (max - min) / float64(bins)
-	buckets := make([]Bucket, bins)
-	for i := range buckets {
-		bmin, bmax := float64(i)*scale+min, float64(i+1)*scale+min
-		buckets[i] = Bucket{Min: bmin, Max: bmax}
-	}
+	buckets := linearBuckets(bins, min, max)
 
-	minC, maxC := 0, 0
-	for _, val := range input {
-		minx := float64(min)
-		xdiff := val - minx
-		bi := imin(int(xdiff/scale), len(buckets)-1)
+	minC, maxC, count, sum := 0.0, 0.0, 0.0, 0.0
+	for i, v := range input {
+		val := float64(v)
+		bi := linearIndex(val, min, scale, len(buckets))
 		if bi < 0 || bi >= len(buckets) {
-			log.Panicf("bi=%d\tval=%f\txdiff=%f\tscale=%f\tlen(buckets)=%d", bi, val, xdiff, scale, len(buckets))
+			log.Panicf("bi=%d\tval=%f\tmin=%f\tscale=%f\tlen(buckets)=%d", bi, val, min, scale, len(buckets))
 		}
-		buckets[bi].Count++
-		minC = imin(minC, buckets[bi].Count)
-		maxC = imax(maxC, buckets[bi].Count)
+		buckets[bi].Count += weight(i)
+		count += weight(i)
+		sum += val * weight(i)
+		minC = math.Min(minC, buckets[bi].Count)
+		maxC = math.Max(maxC, buckets[bi].Count)
 	}
 
 	return Histogram{
 		Min:     minC,
 		Max:     maxC,
-		Count:   len(input),
+		Count:   count,
+		Sum:     sum,
 		Buckets: buckets,
 	}
 }
 
 // PowerHist creates an histogram partionning input over buckets of power
 // `pow`.
+//
+// Like on a symmetric log axis, negative values are partitionned over
+// buckets bounded by negative powers, and zeros are counted in a bucket
+// of their own. NaN and infinite values are counted apart from the
+// buckets.
+//
+// Unlike those of Hist, the last bucket excludes its high bound like
+// the others. PowerHist gives an empty histogram for powers of at most
+// 0, and panics on powers in (0, 1], for which buckets wouldn't grow.
 func PowerHist(power float64, input []float64) Histogram {
 	if len(input) == 0 || power <= 0 {
 		return Histogram{}
 	}
+	if !(power > 1) {
+		log.Panicf("histogram: invalid power histogram, power=%f", power)
+	}
 
-	minx, maxx := input[0], input[0]
+	var (
+		nan, inf   int
+		zeros, sum float64
+		neg, pos   []float64
+	)
 	for _, val := range input {
-		minx = math.Min(minx, val)
-		maxx = math.Max(maxx, val)
+		switch {
+		case math.IsNaN(val):
+			nan++
+		case math.IsInf(val, 0):
+			inf++
+		case val < 0:
+			sum += val
+			neg = append(neg, -val)
+		case val == 0:
+			zeros++
+		default:
+			sum += val
+			pos = append(pos, val)
+		}
 	}
 
-	fromPower := math.Floor(logbase(minx, power))
-	toPower := math.Floor(logbase(maxx, power))
+	// negative buckets mirror the powers of the absolute values,
+	// from the most negative to the closest to zero
+	negBuckets := powerHistBuckets(power, neg)
+	for i, j := 0, len(negBuckets)-1; i < j; i, j = i+1, j-1 {
+		negBuckets[i], negBuckets[j] = negBuckets[j], negBuckets[i]
+	}
+	for i, bkt := range negBuckets {
+		negBuckets[i] = Bucket{Count: bkt.Count, Min: -bkt.Max, Max: -bkt.Min}
+	}
 
-	buckets := make([]Bucket, int(toPower-fromPower)+1)
-	for i, bkt := range buckets {
-		bkt.Min = math.Pow(power, float64(i)+fromPower)
-		bkt.Max = math.Pow(power, float64(i+1)+fromPower)
-		buckets[i] = bkt
+	buckets := negBuckets
+	if zeros > 0 {
+		buckets = append(buckets, Bucket{Count: zeros, Min: 0, Max: 0})
 	}
+	buckets = append(buckets, powerHistBuckets(power, pos)...)
+
+	h := newHistogram(buckets)
+	h.Sum = sum
+	h.NaN = nan
+	h.Inf = inf
+	h.halfOpen = true
+	return h
+}
+
+// powerHistBuckets partitions positive, finite input over buckets of
+// power `pow`.
+func powerHistBuckets(power float64, input []float64) []Bucket {
+	if len(input) == 0 {
+		return nil
+	}
+	minx, maxx := minmax(input)
+
+	fromPower := math.Floor(logbase(minx, power))
+	buckets := powerBuckets(power, minx, maxx)
 
-	minC := 0
-	maxC := 0
 	for _, val := range input {
 		powAway := logbase(val, power) - fromPower
 		bi := int(math.Floor(powAway))
+		// guard against rounding errors of the logarithm
+		bi = imax(0, imin(bi, len(buckets)-1))
 		buckets[bi].Count++
-		minC = imin(buckets[bi].Count, minC)
-		maxC = imax(buckets[bi].Count, maxC)
 	}
+	return buckets
+}
 
-	return Histogram{
-		Min:     minC,
-		Max:     maxC,
-		Count:   len(input),
-		Buckets: buckets,
+// HistEdges creates an histogram partitionning input over the buckets
+// bounded by edges, which must be sorted in increasing order. Values
+// below or above the edges are counted in buckets bounded by -Inf and
+// +Inf, which are omitted if they are empty.
+func HistEdges(edges []float64, input []float64) Histogram {
+	rec := NewRecorder(EdgesLayout(edges))
+	for _, val := range input {
+		rec.Record(val)
 	}
+	h := rec.Snapshot()
+	h.Buckets = trimOpen(h.Buckets)
+	return h
 }
 
 // Scale gives the scaled count of the bucket at idx, using the
@@ -128,17 +233,50 @@ func (h Histogram) Scale(s ScaleFunc, idx int) float64 {
 }
 
 // ScaleFunc is the type to implement to scale an histogram.
-type ScaleFunc func(min, max, value int) float64
+type ScaleFunc func(min, max, value float64) float64
 
 // Linear builds a ScaleFunc that will linearly scale the values of
 // an histogram so that they do not exceed width.
 func Linear(width int) ScaleFunc {
-	return func(min, max, value int) float64 {
+	return func(min, max, value float64) float64 {
 		if min == max {
 			return 1
 		}
-		return float64(value-min) / float64(max-min) * float64(width)
+		return (value - min) / (max - min) * float64(width)
+	}
+}
+
+// linearBuckets partitions [min, max] in `bins` buckets of equal width.
+func linearBuckets(bins int, min, max float64) []Bucket {
+	scale := (max - min) / float64(bins)
+	buckets := make([]Bucket, bins)
+	for i := range buckets {
+		bmin, bmax := float64(i)*scale+min, float64(i+1)*scale+min
+		buckets[i] = Bucket{Min: bmin, Max: bmax}
+	}
+	return buckets
+}
+
+// linearIndex gives the index of the bucket holding val, out of
+// `bins` buckets of width scale starting at min. The last bucket
+// holds the max value.
+func linearIndex(val, min, scale float64, bins int) int {
+	return imin(int((val-min)/scale), bins-1)
+}
+
+// powerBuckets partitions [min, max] in buckets bounded by
+// successive powers of `power`.
+func powerBuckets(power, min, max float64) []Bucket {
+	fromPower := math.Floor(logbase(min, power))
+	toPower := math.Floor(logbase(max, power))
+
+	buckets := make([]Bucket, int(toPower-fromPower)+1)
+	for i, bkt := range buckets {
+		bkt.Min = math.Pow(power, float64(i)+fromPower)
+		bkt.Max = math.Pow(power, float64(i+1)+fromPower)
+		buckets[i] = bkt
 	}
+	return buckets
 }
 
 func logbase(a, base float64) float64 {
@@ -1,7 +1,10 @@
 package histogram
 
 import (
+	"fmt"
+	"math"
 	"os"
+	"testing"
 	"time"
 )
 
@@ -80,3 +83,137 @@ func ExampleHist_duration() {
 	// 800ms-900ms  4.55%  ▋       1
 	// 900ms-1s     9.09%  █▏      2
 }
+
+func ExampleHistogram_Percentiles() {
+	hist := Hist(10, []float64{
+		1, 2, 2, 3, 3, 3, 4, 4, 4, 4,
+		5, 5, 5, 6, 6, 7, 8, 9, 10, 20,
+	})
+
+	for i, v := range hist.Percentiles(0.5, 0.9, 0.99) {
+		fmt.Printf("p%d: %.3g\n", []int{50, 90, 99}[i], v)
+	}
+	// Output:
+	// p50: 4.8
+	// p90: 9.55
+	// p99: 19.6
+}
+
+func ExampleMarkPercentiles() {
+	hist := Hist(5, []float64{
+		1, 2, 2, 3, 3, 3, 4, 4, 4, 4,
+		5, 5, 5, 6, 6, 7, 8, 9, 10, 20,
+	})
+
+	if err := Fprint(os.Stdout, hist, Linear(10), MarkPercentiles(0.5, 0.9, 0.99)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1-4.8      50%  ██████████▏  10  ◀ p50
+	// 4.8-8.6    35%  ███████▏     7
+	// 8.6-12.4   10%  ██▏          2  ◀ p90
+	// 12.4-16.2  0%   ▏
+	// 16.2-20    5%   █▏           1  ◀ p99
+}
+
+func ExampleHistEdges() {
+	slo := []float64{
+		float64(5 * time.Millisecond),
+		float64(10 * time.Millisecond),
+		float64(25 * time.Millisecond),
+		float64(50 * time.Millisecond),
+		float64(100 * time.Millisecond),
+		float64(250 * time.Millisecond),
+		float64(time.Second),
+	}
+	data := []float64{
+		float64(2 * time.Millisecond),
+		float64(6 * time.Millisecond),
+		float64(7 * time.Millisecond),
+		float64(8 * time.Millisecond),
+		float64(12 * time.Millisecond),
+		float64(20 * time.Millisecond),
+		float64(30 * time.Millisecond),
+		float64(120 * time.Millisecond),
+		float64(3 * time.Second),
+	}
+
+	hist := HistEdges(slo, data)
+
+	err := Fprintf(os.Stdout, hist, Linear(6), func(v float64) string {
+		return time.Duration(v).String()
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// <5ms         11.1%  ██▏      1
+	// 5ms-10ms     33.3%  ██████▏  3
+	// 10ms-25ms    22.2%  ████▏    2
+	// 25ms-50ms    11.1%  ██▏      1
+	// 50ms-100ms   0%     ▏
+	// 100ms-250ms  11.1%  ██▏      1
+	// 250ms-1s     0%     ▏
+	// >1s          11.1%  ██▏      1
+}
+
+func ExamplePowerHist() {
+	deltas := []float64{
+		-250, -30, -12,
+		0, 0, 0,
+		0.5,
+		3, 4, 5, 8,
+		20, 60, 70,
+		math.NaN(),
+	}
+
+	hist := PowerHist(10, deltas)
+
+	if err := Fprint(os.Stdout, hist, Linear(5)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// -1000--100  7.14%  █▎      1
+	// -100--10    14.3%  ██▋     2
+	// 0           21.4%  ███▊    3
+	// 0.1-1       7.14%  █▎      1
+	// 1-10        28.6%  █████▏  4
+	// 10-100      21.4%  ███▊    3
+	// NaN                        1
+}
+
+func ExampleHistWeighted() {
+	// bytes per request, for sampled traces whose weight is the
+	// inverse of their sampling rate
+	sizes := []float64{120, 450, 800, 1500, 2100, 4000}
+	weights := []float64{100, 10, 10, 2.5, 1, 0.5}
+
+	hist := HistWeighted(4, sizes, weights)
+
+	if err := Fprint(os.Stdout, hist, Linear(10)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 120-1090   96.8%   ██████████▏  120
+	// 1090-2060  2.02%   ▎            2.5
+	// 2060-3030  0.806%  ▏            1
+	// 3030-4000  0.403%  ▏            0.5
+}
+
+func TestPowerHistInvalidPower(t *testing.T) {
+	for _, power := range []float64{1, 0.5, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("power=%v: want a panic", power)
+				}
+			}()
+			PowerHist(power, []float64{1, 10, 100})
+		}()
+	}
+	for _, power := range []float64{0, -2} {
+		if h := PowerHist(power, []float64{1, 10, 100}); len(h.Buckets) != 0 {
+			t.Errorf("power=%v: want an empty histogram, got %+v", power, h)
+		}
+	}
+}
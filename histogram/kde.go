@@ -0,0 +1,168 @@
+package histogram
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Kernel is the shape of the bump that a kernel density estimate
+// stacks on each value.
+type Kernel struct {
+	density func(u float64) float64
+	// support is how many bandwidths away from a value its bump
+	// is worth drawing
+	support float64
+}
+
+var (
+	// Gaussian stacks a normal curve on each value. Its bumps are
+	// drawn up to 3 bandwidths away.
+	Gaussian = Kernel{density: gaussian, support: 3}
+	// Epanechnikov stacks a parabola on each value, which is 0 past
+	// a bandwidth away.
+	Epanechnikov = Kernel{density: epanechnikov, support: 1}
+)
+
+func gaussian(u float64) float64 {
+	return math.Exp(-u*u/2) / math.Sqrt(2*math.Pi)
+}
+
+func epanechnikov(u float64) float64 {
+	if math.Abs(u) > 1 {
+		return 0
+	}
+	return 0.75 * (1 - u*u)
+}
+
+// Silverman picks a bandwidth for a kernel density estimate of input
+// by Silverman's rule of thumb, 0.9·min(σ, IQR/1.34)·n^(-1/5), which
+// suits roughly normal data sets.
+func Silverman(input []float64) float64 {
+	if len(input) == 0 {
+		return 1
+	}
+	sorted := sortedCopy(input)
+	sd := stddev(sorted)
+	iqr := quantileSorted(sorted, 0.75) - quantileSorted(sorted, 0.25)
+	spread := math.Min(sd, iqr/1.34)
+	if spread == 0 {
+		spread = sd
+	}
+	if spread == 0 {
+		// all the values are the same, any bandwidth will do
+		return 1
+	}
+	return 0.9 * spread * math.Pow(float64(len(input)), -0.2)
+}
+
+// KDE creates a kernel density estimate of input, sampled over `bins`
+// buckets of equal width. Unlike with Hist, the shape of the estimate
+// barely depends on the number of buckets, which makes it suited to
+// small data sets.
+//
+// Each value is spread over its neighbouring buckets following
+// kernel, `bandwidth` being the scale of the spread. If bandwidth is
+// not positive, it's picked by Silverman's rule. The count of each
+// bucket is the expected number of values in it, so the counts add up
+// to about the number of values. NaN and infinite values are counted
+// apart.
+func KDE(bins int, kernel Kernel, bandwidth float64, input []float64) Histogram {
+	var (
+		finite   = make([]float64, 0, len(input))
+		nan, inf int
+		sum      float64
+	)
+	for _, v := range input {
+		switch {
+		case math.IsNaN(v):
+			nan++
+		case math.IsInf(v, 0):
+			inf++
+		default:
+			finite = append(finite, v)
+			sum += v
+		}
+	}
+	if len(finite) == 0 || bins <= 0 {
+		return Histogram{NaN: nan, Inf: inf}
+	}
+	if !(bandwidth > 0) {
+		bandwidth = Silverman(finite)
+	}
+
+	min, max := minmax(finite)
+	reach := kernel.support * bandwidth
+	buckets := linearBuckets(bins, min-reach, max+reach)
+	for i, bkt := range buckets {
+		mid := (bkt.Min + bkt.Max) / 2
+		var density float64
+		for _, v := range finite {
+			density += kernel.density((mid - v) / bandwidth)
+		}
+		buckets[i].Count = density * (bkt.Max - bkt.Min) / bandwidth
+	}
+
+	h := newHistogram(buckets)
+	h.Sum = sum
+	h.NaN = nan
+	h.Inf = inf
+	return h
+}
+
+// Rug draws a mark for each of the samples in the bucket holding it,
+// like the rug under a density plot. It's meant to show where the
+// values of a small data set sit on a KDE.
+func Rug(samples []float64) Option {
+	return func(o *options) { o.rug = append(o.rug, samples...) }
+}
+
+// rugCounts gives how many of the rug samples are in each bucket of h.
+func (o options) rugCounts(h Histogram) []int {
+	counts := make([]int, len(h.Buckets))
+	n := len(h.Buckets)
+	for _, v := range o.rug {
+		if n == 0 || math.IsNaN(v) {
+			continue
+		}
+		i := sort.Search(n, func(i int) bool { return h.Buckets[i].Max > v })
+		if i == n && v == h.Buckets[n-1].Max {
+			// the last bucket includes its high bound
+			i = n - 1
+		}
+		if i < n && v >= h.Buckets[i].Min {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// rugColumns gives the rug marks to print after each bucket of h, as
+// a column of their own.
+func (o options) rugColumns(h Histogram) []string {
+	cols := make([]string, len(h.Buckets))
+	if len(o.rug) == 0 {
+		return cols
+	}
+	for i, c := range o.rugCounts(h) {
+		cols[i] = "\t" + strings.Repeat("|", c)
+	}
+	return cols
+}
+
+// rugLine gives the rug marks to print under the X axis of a vertical
+// histogram, a column per bucket.
+func (o options) rugLine(h Histogram) string {
+	if len(o.rug) == 0 {
+		return ""
+	}
+	var line strings.Builder
+	for _, c := range o.rugCounts(h) {
+		if c > 0 {
+			line.WriteByte('|')
+		} else {
+			line.WriteByte(' ')
+		}
+	}
+	return line.String()
+}
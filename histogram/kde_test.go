@@ -0,0 +1,51 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+)
+
+// durations of a few benchmark runs, in ms
+var benchRuns = []float64{
+	10.2, 10.4, 10.5, 10.5, 10.7, 10.9, 11.0, 11.1,
+	12.8, 13.0, 13.1, 13.3,
+}
+
+func ExampleKDE() {
+	kde := KDE(30, Gaussian, 0, benchRuns)
+	if err := FprintVertical(os.Stdout, kde, 5, Rug(benchRuns)); err != nil {
+		panic(err)
+	}
+	// Output:
+	//  1.042 ┤        ▂▆█▅
+	//        │       ▂████▇
+	// 0.6251 ┤      ▂██████▇▁   ▁▄▅▄▁
+	//        │     ▃█████████▅▄▆█████▅
+	//        │  ▁▃▆████████████████████▅▂▁
+	//      0 └──────────────────────────────
+	//                 |||||      |||
+	//         8.302 9.681 11.06 12.44   15.2
+}
+
+func ExampleKDE_epanechnikov() {
+	kde := KDE(8, Epanechnikov, 0.5, benchRuns)
+	f := func(v float64) string { return fmt.Sprintf("%.1fms", v) }
+	if err := Fprintf(os.Stdout, kde, Linear(10), f, Rug(benchRuns)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 9.7ms-10.2ms   6.47%  ██▏          0.7493  |
+	// 10.2ms-10.7ms  31.4%  ██████████▏  3.634   ||||
+	// 10.7ms-11.2ms  24.9%  ████████     2.88    |||
+	// 11.2ms-11.8ms  2.69%  ▉            0.3111
+	// 11.8ms-12.3ms  0%     ▏
+	// 12.3ms-12.8ms  5.03%  █▋           0.5821
+	// 12.8ms-13.3ms  23.1%  ███████▍     2.671   |||
+	// 13.3ms-13.8ms  6.47%  ██▏          0.7493  |
+}
+
+func ExampleSilverman() {
+	fmt.Printf("%.3f\n", Silverman(benchRuns))
+	// Output:
+	// 0.633
+}
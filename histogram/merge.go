@@ -0,0 +1,205 @@
+package histogram
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrEdgesMismatch is returned when combining histograms whose
+	// buckets don't have the same edges. Rebin them first.
+	ErrEdgesMismatch = errors.New("histogram: buckets have different edges")
+	// ErrNegativeCount is returned when subtracting an histogram
+	// that counted more values than the one it's subtracted from.
+	ErrNegativeCount = errors.New("histogram: subtraction yields a negative count")
+)
+
+// Merge adds the counts of a and b, which must have buckets with the
+// same edges. Merging with an empty histogram yields the other one.
+func Merge(a, b Histogram) (Histogram, error) {
+	return combine(a, b, func(x, y float64) float64 { return x + y })
+}
+
+// Sub removes the counts of b from a, which must have buckets with
+// the same edges. This is useful to get the values recorded between
+// two snapshots of the same Recorder.
+func Sub(a, b Histogram) (Histogram, error) {
+	return combine(a, b, func(x, y float64) float64 { return x - y })
+}
+
+func combine(a, b Histogram, op func(x, y float64) float64) (Histogram, error) {
+	switch {
+	case len(b.Buckets) == 0:
+		return a, nil
+	case len(a.Buckets) == 0:
+		a.Buckets = make([]Bucket, len(b.Buckets))
+		for i, bkt := range b.Buckets {
+			a.Buckets[i] = Bucket{Min: bkt.Min, Max: bkt.Max}
+		}
+		a.halfOpen = b.halfOpen
+	}
+	if !sameEdges(a, b) {
+		return Histogram{}, ErrEdgesMismatch
+	}
+	buckets := make([]Bucket, len(a.Buckets))
+	for i, bkt := range a.Buckets {
+		bkt.Count = op(bkt.Count, b.Buckets[i].Count)
+		if bkt.Count < 0 {
+			return Histogram{}, ErrNegativeCount
+		}
+		buckets[i] = bkt
+	}
+	h := newHistogram(buckets)
+	// the last bucket holds values at its high bound if either did
+	h.halfOpen = a.halfOpen && b.halfOpen
+	h.Sum = op(a.Sum, b.Sum)
+	h.NaN = int(op(float64(a.NaN), float64(b.NaN)))
+	h.Inf = int(op(float64(a.Inf), float64(b.Inf)))
+	if h.NaN < 0 || h.Inf < 0 {
+		return Histogram{}, ErrNegativeCount
+	}
+	return h, nil
+}
+
+func sameEdges(a, b Histogram) bool {
+	if len(a.Buckets) != len(b.Buckets) {
+		return false
+	}
+	for i, bkt := range a.Buckets {
+		if bkt.Min != b.Buckets[i].Min || bkt.Max != b.Buckets[i].Max {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeRebin adds the counts of a and b after rebinning them over
+// the union of their edges.
+func MergeRebin(a, b Histogram) Histogram {
+	edges := unionEdges(a, b)
+	if len(edges) < 2 {
+		return newHistogram(nil)
+	}
+	// trim the open buckets once merged, as either histogram may
+	// have values in them
+	l := EdgesLayout(edges)
+	h, err := Merge(rebinOpen(a, l), rebinOpen(b, l))
+	if err != nil {
+		// both have been rebinned over the same layout
+		panic(err)
+	}
+	return withTotals(newHistogram(trimOpen(h.Buckets)), h)
+}
+
+// unionEdges gives the sorted finite edges of all the buckets of hs.
+func unionEdges(hs ...Histogram) []float64 {
+	seen := make(map[float64]bool)
+	var edges []float64
+	for _, h := range hs {
+		for _, bkt := range h.Buckets {
+			for _, e := range []float64{bkt.Min, bkt.Max} {
+				if !math.IsInf(e, 0) && !seen[e] {
+					seen[e] = true
+					edges = append(edges, e)
+				}
+			}
+		}
+	}
+	sort.Float64s(edges)
+	return edges
+}
+
+// Rebin spreads the counts of h over the buckets bounded by edges,
+// assuming values are uniformly distributed within each bucket of h.
+// Counts that fall below or above edges are put in underflow and
+// overflow buckets, which are omitted when they're empty. The total
+// count of h is preserved. The counts of weighted histograms are
+// spread as they are, while whole counts are rounded so that they
+// stay whole.
+func Rebin(h Histogram, edges []float64) Histogram {
+	return withTotals(newHistogram(trimOpen(rebin(h, EdgesLayout(edges)))), h)
+}
+
+// rebinOpen spreads the counts of h over the buckets of l, keeping
+// its underflow and overflow buckets even when they're empty.
+func rebinOpen(h Histogram, l Layout) Histogram {
+	return withTotals(newHistogram(rebin(h, l)), h)
+}
+
+// withTotals gives h with the sum and the counts of values that fit
+// in no bucket of from.
+func withTotals(h, from Histogram) Histogram {
+	h.Sum = from.Sum
+	h.NaN = from.NaN
+	h.Inf = from.Inf
+	return h
+}
+
+// rebin spreads the counts of h over the buckets of l, including its
+// underflow and overflow buckets.
+func rebin(h Histogram, l Layout) []Bucket {
+	buckets := l.buckets()
+
+	whole := h.wholeCounts()
+	cumul := make([]float64, len(l.edges))
+	for i, e := range l.edges {
+		cumul[i] = h.countBelow(e, i == len(l.edges)-1)
+		if whole {
+			// rounding the cumulative counts rather than each
+			// count ensures that no value is lost or made up
+			cumul[i] = math.Floor(cumul[i] + 0.5)
+		}
+	}
+	buckets[0].Count = cumul[0]
+	for i := 1; i < len(cumul); i++ {
+		buckets[i].Count = cumul[i] - cumul[i-1]
+	}
+	buckets[len(buckets)-1].Count = h.Count - cumul[len(cumul)-1]
+	return buckets
+}
+
+// wholeCounts tells if all the counts of h are whole numbers, as they
+// are unless h is weighted.
+func (h Histogram) wholeCounts() bool {
+	for _, bkt := range h.Buckets {
+		if bkt.Count != math.Trunc(bkt.Count) {
+			return false
+		}
+	}
+	return true
+}
+
+// countBelow estimates how many values of h are below x, or at x if
+// inclusive is set.
+func (h Histogram) countBelow(x float64, inclusive bool) float64 {
+	var n float64
+	for _, bkt := range h.Buckets {
+		n += bkt.Count * bkt.fractionBelow(x, inclusive)
+	}
+	return n
+}
+
+// fractionBelow estimates the fraction of the values of the bucket
+// that are below x, or at x if inclusive is set. Values are assumed
+// to be uniformly distributed in the bucket, or to sit at the finite
+// bound of underflow and overflow buckets.
+func (b Bucket) fractionBelow(x float64, inclusive bool) float64 {
+	switch {
+	case math.IsInf(b.Min, -1):
+		if x >= b.Max {
+			return 1
+		}
+		return 0
+	case math.IsInf(b.Max, 1):
+		if x > b.Min {
+			return 1
+		}
+		return 0
+	case x > b.Max, x == b.Max && (inclusive || b.Min < b.Max):
+		return 1
+	case x <= b.Min:
+		return 0
+	}
+	return (x - b.Min) / (b.Max - b.Min)
+}
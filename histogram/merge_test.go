@@ -0,0 +1,105 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+)
+
+func ExampleMerge() {
+	layout := LinearLayout(4, 0, 1)
+	worker1, worker2 := NewRecorder(layout), NewRecorder(layout)
+	for _, v := range []float64{0.1, 0.2, 0.3, 0.6} {
+		worker1.Record(v)
+	}
+	for _, v := range []float64{0.3, 0.4, 0.8, 0.9, 2} {
+		worker2.Record(v)
+	}
+
+	cluster, err := Merge(worker1.Snapshot(), worker2.Snapshot())
+	if err != nil {
+		panic(err)
+	}
+	if err := Fprint(os.Stdout, cluster, Linear(4)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0        0%     ▏
+	// 0-0.25    22.2%  ██▋    2
+	// 0.25-0.5  33.3%  ████▏  3
+	// 0.5-0.75  11.1%  █▍     1
+	// 0.75-1    22.2%  ██▋    2
+	// >1        11.1%  █▍     1
+}
+
+func ExampleSub() {
+	rec := NewRecorder(LinearLayout(2, 0, 1))
+	rec.Record(0.2)
+	before := rec.Snapshot()
+	rec.Record(0.7)
+	rec.Record(0.8)
+	after := rec.Snapshot()
+
+	diff, err := Sub(after, before)
+	if err != nil {
+		panic(err)
+	}
+	for _, bkt := range diff.Buckets {
+		fmt.Println(bkt.Min, bkt.Max, bkt.Count)
+	}
+	fmt.Println("count:", diff.Count)
+	// Output:
+	// -Inf 0 0
+	// 0 0.5 0
+	// 0.5 1 2
+	// 1 +Inf 0
+	// count: 2
+}
+
+func ExampleMergeRebin() {
+	a := Hist(2, []float64{0, 1, 2, 3})
+	b := Hist(1, []float64{1, 3})
+
+	h := MergeRebin(a, b)
+	for _, bkt := range h.Buckets {
+		fmt.Println(bkt.Min, bkt.Max, bkt.Count)
+	}
+	fmt.Println("count:", h.Count)
+	// Output:
+	// 0 1 1
+	// 1 1.5 2
+	// 1.5 3 3
+	// count: 6
+}
+
+func ExampleMergeRebin_overflow() {
+	layout := LinearLayout(2, 0, 1)
+	a, b := NewRecorder(layout), NewRecorder(layout)
+	a.Record(0.2)
+	a.Record(3)
+	b.Record(0.7)
+
+	h := MergeRebin(a.Snapshot(), b.Snapshot())
+	for _, bkt := range h.Buckets {
+		fmt.Println(bkt.Min, bkt.Max, bkt.Count)
+	}
+	fmt.Println("count:", h.Count)
+	// Output:
+	// 0 0.5 1
+	// 0.5 1 1
+	// 1 +Inf 1
+	// count: 3
+}
+
+func ExampleRebin_weighted() {
+	h := HistWeighted(2, []float64{0, 1, 2, 3}, []float64{0.5, 1, 1, 1.5})
+
+	rebinned := Rebin(h, []float64{0, 0.5, 3})
+	for _, bkt := range rebinned.Buckets {
+		fmt.Printf("%g %g %.3g\n", bkt.Min, bkt.Max, bkt.Count)
+	}
+	fmt.Println("count:", rebinned.Count)
+	// Output:
+	// 0 0.5 0.5
+	// 0.5 3 3.5
+	// count: 4
+}
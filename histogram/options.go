@@ -0,0 +1,156 @@
+package histogram
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Option customizes how an histogram is printed.
+type Option func(*options)
+
+type options struct {
+	percentiles []float64
+	cumulative  bool
+	legend      string
+	summary     *Summary
+	rug         []float64
+	collapse    int
+	trimEmpty   bool
+	fitWidth    int
+	fitTerminal bool
+
+	scale     ScaleFunc
+	format    FormatFunc
+	hidden    uint
+	align     Alignment
+	ranges    RangeStyle
+	separator string
+	padding   *int
+	empty     *string
+	glyphs    GlyphSet
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// MarkPercentiles flags the buckets holding the quantiles qs, each in
+// [0, 1]. For instance, MarkPercentiles(0.5, 0.99) prints:
+//
+//	100ms-200ms  4.55%  ▋       1
+//	200ms-300ms  27.3%  ███▍    6
+//	300ms-400ms  4.55%  ▋       1
+//	400ms-500ms  4.55%  ▋       1
+//	500ms-600ms  40.9%  █████▏  9  ◀ p50
+//	...
+//	900ms-1s     9.09%  █▏      2  ◀ p99
+func MarkPercentiles(qs ...float64) Option {
+	return func(o *options) { o.percentiles = append(o.percentiles, qs...) }
+}
+
+// marks gives the percentile marks to print after each bucket of h.
+func (o options) marks(h Histogram) []string {
+	names := make([][]string, len(h.Buckets))
+	for _, q := range o.percentiles {
+		if i, _ := h.quantile(q); i >= 0 {
+			names[i] = append(names[i], fmt.Sprintf("p%.4g", q*100))
+		}
+	}
+	marks := make([]string, len(h.Buckets))
+	for i, n := range names {
+		if len(n) > 0 {
+			marks[i] = "\t◀ " + strings.Join(n, " ")
+		}
+	}
+	return marks
+}
+
+// CumulativePercent prints the running count of values up to each
+// bucket, so that the percent column reads as the share of values
+// below the high bound of the bucket.
+func CumulativePercent() Option {
+	return func(o *options) { o.cumulative = true }
+}
+
+// ScaleLegend prints a legend under the chart naming the scale the
+// bars are drawn with, such as `scale: log`, so that readers know a
+// bar twice as long doesn't hold twice as many values. The name is
+// printed as given, for instance `log` or `linear, clamped at p80`.
+func ScaleLegend(name string) Option {
+	return func(o *options) { o.legend = name }
+}
+
+// legendLine gives the line to print under the chart, if any.
+func (o options) legendLine() string {
+	if o.legend == "" {
+		return ""
+	}
+	return "scale: " + o.legend + "\n"
+}
+
+// CollapseEmpty folds runs of at least `run` consecutive empty buckets
+// in a single line, so that sparse histograms fit on screen:
+//
+//	110ms-205ms    60%    █████▏  9
+//	205ms-299ms    13.3%  █▏      2
+//	… 16 empty buckets (299ms–1.811s) …
+//	1.811s-1.906s  6.67%  ▋       1
+//	1.906s-2s      20%    █▋      3
+//
+// Runs are folded only by Fprint and Fprintf, and run can't be less
+// than 2. With CumulativePercent, buckets are folded when their
+// running count is 0, as that's the count printed.
+func CollapseEmpty(run int) Option {
+	return func(o *options) { o.collapse = imax(run, 2) }
+}
+
+// TrimEmpty hides the empty buckets before the first bucket holding
+// values, and after the last one.
+func TrimEmpty() Option {
+	return func(o *options) { o.trimEmpty = true }
+}
+
+// trim drops the leading and trailing empty buckets of h, if asked to.
+func (o options) trim(h Histogram) Histogram {
+	if !o.trimEmpty {
+		return h
+	}
+	first, last := 0, len(h.Buckets)
+	for first < last && h.Buckets[first].Count == 0 {
+		first++
+	}
+	for last > first && h.Buckets[last-1].Count == 0 {
+		last--
+	}
+	// the bucket that includes its high bound may be trimmed, then
+	// none of those left does
+	if lastFinite(h) >= last {
+		h.halfOpen = true
+	}
+	h.Buckets = h.Buckets[first:last]
+	return h
+}
+
+// fold gives the runs of empty buckets of h to print as single lines,
+// by the index of their first bucket, and how many buckets they span.
+func (o options) fold(h Histogram) map[int]int {
+	runs := make(map[int]int)
+	if o.collapse == 0 {
+		return runs
+	}
+	for i := 0; i < len(h.Buckets); {
+		n := 0
+		for i+n < len(h.Buckets) && h.Buckets[i+n].Count == 0 {
+			n++
+		}
+		if n >= o.collapse {
+			runs[i] = n
+		}
+		i += imax(n, 1)
+	}
+	return runs
+}
@@ -0,0 +1,268 @@
+package histogram
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNoSuchMetric is returned when reading an exposition that holds
+// no histogram of the requested name.
+var ErrNoSuchMetric = errors.New("histogram: no such histogram metric")
+
+// WritePrometheus writes h on w as a histogram metric named `name`, in
+// the Prometheus text exposition format:
+//
+//	# TYPE latency_seconds histogram
+//	latency_seconds_bucket{le="0.1"} 0
+//	latency_seconds_bucket{le="0.2"} 4
+//	latency_seconds_bucket{le="+Inf"} 5
+//	latency_seconds_sum 0.73
+//	latency_seconds_count 5
+//
+// Bucket counts are cumulative, and each bucket is exposed by its high
+// bound. When the first bucket has a finite low bound, an empty
+// bucket is exposed at that bound so that it survives a round trip.
+// NaN and infinite values that aren't counted in a bucket are left
+// out.
+func WritePrometheus(w io.Writer, name string, h Histogram) error {
+	bw := bufio.NewWriter(w)
+	writeExposition(bw, name, h)
+	return bw.Flush()
+}
+
+// WriteOpenMetrics is the same as WritePrometheus, but terminates the
+// exposition with the `# EOF` line that OpenMetrics requires. Use it
+// when h is the only metric of the exposition.
+func WriteOpenMetrics(w io.Writer, name string, h Histogram) error {
+	bw := bufio.NewWriter(w)
+	writeExposition(bw, name, h)
+	bw.WriteString("# EOF\n")
+	return bw.Flush()
+}
+
+func writeExposition(w *bufio.Writer, name string, h Histogram) {
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for _, b := range cumulativeBounds(h) {
+		if math.IsInf(b.le, 1) {
+			continue
+		}
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %s\n", name, formatValue(b.le), formatValue(b.count))
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %s\n", name, formatValue(h.Count))
+	fmt.Fprintf(w, "%s_sum %s\n", name, formatValue(h.Sum))
+	fmt.Fprintf(w, "%s_count %s\n", name, formatValue(h.Count))
+}
+
+// bound is a cumulative count of values up to le.
+type bound struct {
+	le, count float64
+}
+
+// cumulativeBounds gives the increasing high bounds of the buckets of
+// h with their cumulative counts. Gaps between buckets yield a bound
+// at the low bound of the next bucket, and bounds that repeat keep
+// their last count.
+func cumulativeBounds(h Histogram) []bound {
+	var bounds []bound
+	add := func(le, count float64) {
+		if n := len(bounds); n > 0 && bounds[n-1].le >= le {
+			bounds[n-1].count = count
+			return
+		}
+		bounds = append(bounds, bound{le: le, count: count})
+	}
+	running := 0.0
+	for _, bkt := range h.Buckets {
+		if !math.IsInf(bkt.Min, -1) {
+			add(bkt.Min, running)
+		}
+		running += bkt.Count
+		add(bkt.Max, running)
+	}
+	return bounds
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// ReadPrometheus reads the histogram metric named `name` out of a
+// Prometheus or OpenMetrics text exposition, like a saved scrape of a
+// `/metrics` endpoint. Other metrics are skipped. If the histogram
+// has several series, only the first one is read.
+//
+// Each `le` bucket becomes a bucket bounded by the previous `le`, and
+// the first and last buckets are bounded by -Inf and +Inf. These are
+// omitted if they're empty.
+func ReadPrometheus(r io.Reader, name string) (Histogram, error) {
+	var (
+		series string
+		found  bool
+		bounds []bound
+		sum    float64
+	)
+	scan := bufio.NewScanner(r)
+	for lineno := 1; scan.Scan(); lineno++ {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		metric, labels, value, err := parseSample(line)
+		if err != nil {
+			return Histogram{}, fmt.Errorf("histogram: line %d: %v", lineno, err)
+		}
+		if metric != name+"_bucket" && metric != name+"_sum" {
+			continue
+		}
+
+		le, hasLe := labels["le"]
+		delete(labels, "le")
+		key := seriesKey(labels)
+		if !found {
+			series, found = key, true
+		} else if key != series {
+			continue
+		}
+
+		if metric == name+"_sum" {
+			sum = value
+			continue
+		}
+		if !hasLe {
+			return Histogram{}, fmt.Errorf("histogram: line %d: bucket has no le label", lineno)
+		}
+		upper, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			return Histogram{}, fmt.Errorf("histogram: line %d: invalid le %q", lineno, le)
+		}
+		bounds = append(bounds, bound{le: upper, count: value})
+	}
+	if err := scan.Err(); err != nil {
+		return Histogram{}, err
+	}
+	if len(bounds) == 0 {
+		return Histogram{}, ErrNoSuchMetric
+	}
+
+	h, err := histogramOfBounds(bounds)
+	if err != nil {
+		return Histogram{}, err
+	}
+	h.Sum = sum
+	return h, nil
+}
+
+// histogramOfBounds turns cumulative counts back into buckets.
+func histogramOfBounds(bounds []bound) (Histogram, error) {
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].le < bounds[j].le })
+	if last := bounds[len(bounds)-1]; !math.IsInf(last.le, 1) {
+		return Histogram{}, errors.New("histogram: exposition has no +Inf bucket")
+	}
+
+	buckets := make([]Bucket, 0, len(bounds))
+	prevLe, prevCount := math.Inf(-1), 0.0
+	for _, b := range bounds {
+		if b.le == prevLe {
+			return Histogram{}, fmt.Errorf("histogram: bucket le=%s is repeated", formatValue(b.le))
+		}
+		if b.count < prevCount {
+			return Histogram{}, ErrNegativeCount
+		}
+		buckets = append(buckets, Bucket{Count: b.count - prevCount, Min: prevLe, Max: b.le})
+		prevLe, prevCount = b.le, b.count
+	}
+	return newHistogram(trimOpen(buckets)), nil
+}
+
+// parseSample splits a sample line in its metric name, labels and
+// value. A trailing timestamp is ignored.
+func parseSample(line string) (metric string, labels map[string]string, value float64, err error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end < 0 {
+		return "", nil, 0, errors.New("sample has no value")
+	}
+	metric, line = line[:end], line[end:]
+
+	labels = make(map[string]string)
+	if line[0] == '{' {
+		line, err = parseLabels(line[1:], labels)
+		if err != nil {
+			return "", nil, 0, err
+		}
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, 0, errors.New("sample has no value")
+	}
+	value, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("invalid value %q", fields[0])
+	}
+	return metric, labels, value, nil
+}
+
+// parseLabels reads `name="value"` pairs into labels up to the closing
+// brace, and gives what follows it.
+func parseLabels(line string, labels map[string]string) (string, error) {
+	for {
+		line = strings.TrimLeft(line, " \t,")
+		if line == "" {
+			return "", errors.New("unterminated labels")
+		}
+		if line[0] == '}' {
+			return line[1:], nil
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 || eq+1 >= len(line) || line[eq+1] != '"' {
+			return "", errors.New("invalid label")
+		}
+		label := strings.TrimSpace(line[:eq])
+		line = line[eq+2:]
+
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			if c == '"' {
+				line, closed = line[i+1:], true
+				break
+			}
+			if c == '\\' && i+1 < len(line) {
+				i++
+				switch line[i] {
+				case 'n':
+					c = '\n'
+				default:
+					c = line[i]
+				}
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated value of label %q", label)
+		}
+		labels[label] = value.String()
+	}
+}
+
+// seriesKey identifies a series by its sorted labels.
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var key strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&key, "%s=%q,", name, labels[name])
+	}
+	return key.String()
+}
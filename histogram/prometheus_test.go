@@ -0,0 +1,49 @@
+package histogram
+
+import (
+	"os"
+	"strings"
+)
+
+func ExampleWritePrometheus() {
+	h := HistEdges([]float64{0.1, 0.2, 0.4, 0.8}, []float64{0.12, 0.15, 0.18, 0.3, 0.35, 0.5, 1.2})
+	if err := WritePrometheus(os.Stdout, "latency_seconds", h); err != nil {
+		panic(err)
+	}
+	// Output:
+	// # TYPE latency_seconds histogram
+	// latency_seconds_bucket{le="0.1"} 0
+	// latency_seconds_bucket{le="0.2"} 3
+	// latency_seconds_bucket{le="0.4"} 5
+	// latency_seconds_bucket{le="0.8"} 6
+	// latency_seconds_bucket{le="+Inf"} 7
+	// latency_seconds_sum 2.8
+	// latency_seconds_count 7
+}
+
+func ExampleReadPrometheus() {
+	scrape := `# HELP http_request_duration_seconds Time spent serving requests.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{handler="/",le="0.05"} 24
+http_request_duration_seconds_bucket{handler="/",le="0.1"} 33
+http_request_duration_seconds_bucket{handler="/",le="0.25"} 40
+http_request_duration_seconds_bucket{handler="/",le="0.5"} 41
+http_request_duration_seconds_bucket{handler="/",le="+Inf"} 41
+http_request_duration_seconds_sum{handler="/"} 2.43
+http_request_duration_seconds_count{handler="/"} 41
+# TYPE go_goroutines gauge
+go_goroutines 12
+`
+	h, err := ReadPrometheus(strings.NewReader(scrape), "http_request_duration_seconds")
+	if err != nil {
+		panic(err)
+	}
+	if err := Fprint(os.Stdout, h, Linear(10)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0.05     58.5%  ██████████▏  24
+	// 0.05-0.1  22%    ███▊         9
+	// 0.1-0.25  17.1%  ███          7
+	// 0.25-0.5  2.44%  ▌            1
+}
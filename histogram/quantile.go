@@ -0,0 +1,62 @@
+package histogram
+
+import "math"
+
+// Quantile estimates the q-th quantile of the values counted in h,
+// for q in [0, 1]. The value is interpolated linearly within the
+// bucket that holds it. Values in underflow and overflow buckets are
+// estimated at the finite bound of the bucket.
+//
+// Quantile returns NaN if h is empty or q is out of range.
+func (h Histogram) Quantile(q float64) float64 {
+	_, v := h.quantile(q)
+	return v
+}
+
+// Percentiles estimates the quantiles qs of h, each in [0, 1], such
+// that Percentiles(0.5, 0.99) gives the p50 and p99 values.
+func (h Histogram) Percentiles(qs ...float64) []float64 {
+	values := make([]float64, len(qs))
+	for i, q := range qs {
+		values[i] = h.Quantile(q)
+	}
+	return values
+}
+
+// quantile gives the index of the bucket holding the q-th quantile,
+// and the estimated value of the quantile. The index is -1 if there
+// is no such value.
+func (h Histogram) quantile(q float64) (int, float64) {
+	if h.Count == 0 || !(q >= 0 && q <= 1) {
+		return -1, math.NaN()
+	}
+	rank := q * h.Count
+	var seen float64
+	last := -1
+	for i, bkt := range h.Buckets {
+		if bkt.Count <= 0 {
+			continue
+		}
+		last = i
+		count := bkt.Count
+		if seen+count < rank {
+			seen += count
+			continue
+		}
+		switch {
+		case math.IsInf(bkt.Min, -1):
+			return i, bkt.Max
+		case math.IsInf(bkt.Max, 1):
+			return i, bkt.Min
+		}
+		return i, bkt.Min + (rank-seen)/count*(bkt.Max-bkt.Min)
+	}
+	if last >= 0 {
+		// the weights didn't quite add up to Count
+		if bkt := h.Buckets[last]; !math.IsInf(bkt.Max, 1) {
+			return last, bkt.Max
+		}
+		return last, h.Buckets[last].Min
+	}
+	return -1, math.NaN()
+}
@@ -0,0 +1,181 @@
+package histogram
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// Layout describes a fixed partition of values over buckets. The
+// bounds are declared up front, so values can be counted as they
+// come without keeping them in memory.
+type Layout struct {
+	edges []float64
+}
+
+// LinearLayout partitions [min, max] in `bins` buckets of equal width,
+// like Hist does.
+func LinearLayout(bins int, min, max float64) Layout {
+	if bins <= 0 || !(min < max) {
+		log.Panicf("histogram: invalid linear layout, bins=%d\tmin=%f\tmax=%f", bins, min, max)
+	}
+	return layoutOf(linearBuckets(bins, min, max))
+}
+
+// PowerLayout partitions [min, max] in buckets bounded by successive
+// powers of `power`, like PowerHist does.
+func PowerLayout(power, min, max float64) Layout {
+	if power <= 1 || !(min > 0) || !(min <= max) {
+		log.Panicf("histogram: invalid power layout, power=%f\tmin=%f\tmax=%f", power, min, max)
+	}
+	return layoutOf(powerBuckets(power, min, max))
+}
+
+// EdgesLayout partitions values over the buckets bounded by edges.
+// The edges must be sorted in increasing order, and there must be
+// at least two of them.
+func EdgesLayout(edges []float64) Layout {
+	if len(edges) < 2 {
+		log.Panicf("histogram: need at least 2 edges, got %d", len(edges))
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			log.Panicf("histogram: edges must be increasing, edges[%d]=%f\tedges[%d]=%f", i-1, edges[i-1], i, edges[i])
+		}
+	}
+	return Layout{edges: append([]float64(nil), edges...)}
+}
+
+func layoutOf(buckets []Bucket) Layout {
+	edges := make([]float64, 0, len(buckets)+1)
+	for _, bkt := range buckets {
+		edges = append(edges, bkt.Min)
+	}
+	edges = append(edges, buckets[len(buckets)-1].Max)
+	return Layout{edges: edges}
+}
+
+// Edges returns the bounds of the buckets of the layout.
+func (l Layout) Edges() []float64 { return append([]float64(nil), l.edges...) }
+
+// index gives the index of the bucket holding v, counting the
+// underflow bucket as 0 and the overflow bucket as len(l.edges).
+// Like in Hist, the last bucket includes its high bound.
+func (l Layout) index(v float64) int {
+	last := len(l.edges) - 1
+	switch {
+	case v < l.edges[0]:
+		return 0
+	case v > l.edges[last]:
+		return last + 1
+	case v == l.edges[last]:
+		return last
+	}
+	return sort.Search(len(l.edges), func(i int) bool { return l.edges[i] > v })
+}
+
+// buckets creates empty buckets for the layout, including the
+// underflow and overflow buckets.
+func (l Layout) buckets() []Bucket {
+	buckets := make([]Bucket, len(l.edges)+1)
+	buckets[0] = Bucket{Min: math.Inf(-1), Max: l.edges[0]}
+	for i := 1; i < len(l.edges); i++ {
+		buckets[i] = Bucket{Min: l.edges[i-1], Max: l.edges[i]}
+	}
+	buckets[len(l.edges)] = Bucket{Min: l.edges[len(l.edges)-1], Max: math.Inf(1)}
+	return buckets
+}
+
+// Recorder counts values over the buckets of a Layout as they are
+// recorded. Values below or above the layout are counted in an
+// underflow and an overflow bucket.
+//
+// A Recorder is not safe for concurrent use.
+type Recorder struct {
+	layout Layout
+	counts []float64
+	sum    float64
+	// nan is a float64 for the weights of RecordN
+	nan float64
+}
+
+// NewRecorder creates a Recorder counting values over l.
+func NewRecorder(l Layout) *Recorder {
+	return &Recorder{
+		layout: l,
+		counts: make([]float64, len(l.edges)+1),
+	}
+}
+
+// Record counts v in its bucket. NaN values are counted apart.
+func (r *Recorder) Record(v float64) {
+	if math.IsNaN(v) {
+		r.nan++
+		return
+	}
+	r.counts[r.layout.index(v)]++
+	if !math.IsInf(v, 0) {
+		r.sum += v
+	}
+}
+
+// RecordN counts v in its bucket with weight w, as if v had been
+// recorded w times. NaN values are counted apart, with their weight
+// too. The weight must be finite and non-negative.
+func (r *Recorder) RecordN(v, w float64) {
+	checkWeight(w)
+	if math.IsNaN(v) {
+		r.nan += w
+		return
+	}
+	r.counts[r.layout.index(v)] += w
+	if !math.IsInf(v, 0) {
+		r.sum += v * w
+	}
+}
+
+// Reset forgets all the values recorded so far.
+func (r *Recorder) Reset() {
+	for i := range r.counts {
+		r.counts[i] = 0
+	}
+	r.sum = 0
+	r.nan = 0
+}
+
+// checkWeight panics if w can't weigh a value.
+func checkWeight(w float64) {
+	if !(w >= 0) || math.IsInf(w, 1) {
+		log.Panicf("histogram: invalid weight, w=%f", w)
+	}
+}
+
+// Snapshot creates an histogram of the values recorded so far. The
+// first and last buckets of the histogram are the underflow and
+// overflow buckets, bounded by -Inf and +Inf.
+func (r *Recorder) Snapshot() Histogram {
+	buckets := r.layout.buckets()
+	for i, c := range r.counts {
+		buckets[i].Count = c
+	}
+	h := newHistogram(buckets)
+	h.Sum = r.sum
+	h.NaN = int(math.Round(r.nan))
+	return h
+}
+
+// newHistogram tallies the counts of buckets into an Histogram.
+func newHistogram(buckets []Bucket) Histogram {
+	minC, maxC, count := 0.0, 0.0, 0.0
+	for _, bkt := range buckets {
+		minC = math.Min(minC, bkt.Count)
+		maxC = math.Max(maxC, bkt.Count)
+		count += bkt.Count
+	}
+	return Histogram{
+		Min:     minC,
+		Max:     maxC,
+		Count:   count,
+		Buckets: buckets,
+	}
+}
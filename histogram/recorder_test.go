@@ -0,0 +1,121 @@
+package histogram
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+func ExampleRecorder() {
+	rec := NewRecorder(LinearLayout(5, 0, 1))
+	for _, v := range []float64{
+		-0.5,
+		0.1,
+		0.2, 0.21, 0.22, 0.22,
+		0.5, 0.51, 0.52, 0.53,
+		0.9,
+		1.0,
+		2.5, 3,
+	} {
+		rec.Record(v)
+	}
+
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(5)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0       7.14%  █▎      1
+	// 0-0.2    7.14%  █▎      1
+	// 0.2-0.4  28.6%  █████▏  4
+	// 0.4-0.6  28.6%  █████▏  4
+	// 0.6-0.8  0%     ▏
+	// 0.8-1    14.3%  ██▋     2
+	// >1       14.3%  ██▋     2
+}
+
+func ExampleRecorder_empty() {
+	rec := NewRecorder(LinearLayout(3, 0, 3))
+	rec.Record(math.NaN())
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(5)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0   0%  ▏
+	// 0-1  0%  ▏
+	// 1-2  0%  ▏
+	// 2-3  0%  ▏
+	// >3   0%  ▏
+	// NaN         1
+}
+
+func ExampleRecorder_power() {
+	rec := NewRecorder(PowerLayout(10, float64(time.Millisecond), float64(time.Second)))
+	for _, d := range []time.Duration{
+		500 * time.Microsecond,
+		2 * time.Millisecond, 3 * time.Millisecond, 5 * time.Millisecond,
+		20 * time.Millisecond, 40 * time.Millisecond,
+		300 * time.Millisecond,
+		30 * time.Second,
+	} {
+		rec.Record(float64(d))
+	}
+
+	err := Fprintf(os.Stdout, rec.Snapshot(), Linear(6), func(v float64) string {
+		return time.Duration(v).String()
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// <1ms        12.5%  ██▏      1
+	// 1ms-10ms    37.5%  ██████▏  3
+	// 10ms-100ms  25%    ████▏    2
+	// 100ms-1s    12.5%  ██▏      1
+	// 1s-10s      0%     ▏
+	// >10s        12.5%  ██▏      1
+}
+
+func ExampleRecorder_RecordN() {
+	rec := NewRecorder(EdgesLayout([]float64{0, 10, 100, 1000}))
+	// each trace was sampled at 1%, so it stands for 100 requests
+	for _, v := range []float64{5, 8, 40, 300} {
+		rec.RecordN(v, 100)
+	}
+
+	if err := Fprint(os.Stdout, rec.Snapshot(), Linear(4)); err != nil {
+		panic(err)
+	}
+	// Output:
+	// <0        0%   ▏
+	// 0-10      50%  ████▏  200
+	// 10-100    25%  ██▏    100
+	// 100-1000  25%  ██▏    100
+	// >1000     0%   ▏
+}
+
+func TestRecordNNaN(t *testing.T) {
+	rec := NewRecorder(LinearLayout(2, 0, 1))
+	rec.RecordN(math.NaN(), 100)
+	if h := rec.Snapshot(); h.NaN != 100 {
+		t.Fatalf("want 100 NaN values, got %d", h.NaN)
+	}
+}
+
+func TestInvalidWeights(t *testing.T) {
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		for name, record := range map[string]func(){
+			"RecordN":      func() { NewRecorder(LinearLayout(2, 0, 1)).RecordN(0.5, w) },
+			"HistWeighted": func() { HistWeighted(2, []float64{0, 1}, []float64{1, w}) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s with w=%v: want a panic", name, w)
+					}
+				}()
+				record()
+			}()
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package histogram
+
+import (
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aybabtme/uniplot/internal/bars"
+)
+
+// Render prints h on w like Fprint, every aspect of the output being
+// set by opts. Without options, the bars are scaled with Linear(40)
+// and the bounds of the buckets are printed with a %.4g format.
+func Render(w io.Writer, h Histogram, opts ...Option) error {
+	o := newOptions(opts)
+	s, f := o.scale, o.format
+	if s == nil {
+		s = Linear(40)
+	}
+	if f == nil {
+		f = defaultFormat
+	}
+	return fprintf(w, h, s, f, o)
+}
+
+// WithScale scales the bars with s.
+func WithScale(s ScaleFunc) Option {
+	return func(o *options) { o.scale = s }
+}
+
+// WithFormat prints the bounds of the buckets with f.
+func WithFormat(f FormatFunc) Option {
+	return func(o *options) { o.format = f }
+}
+
+// Column is a column of the lines printed by Fprint, such as the
+// percent of values of each bucket.
+type Column int
+
+const (
+	// LabelColumn holds the range of each bucket.
+	LabelColumn Column = iota
+	// PercentColumn holds the share of values of each bucket.
+	PercentColumn
+	// CountColumn holds the count of each bucket.
+	CountColumn
+)
+
+// HideColumns leaves cols out of the lines. The bars are always
+// printed.
+func HideColumns(cols ...Column) Option {
+	return func(o *options) {
+		for _, col := range cols {
+			o.hidden |= 1 << uint(col)
+		}
+	}
+}
+
+func (o options) shows(col Column) bool { return o.hidden&(1<<uint(col)) == 0 }
+
+// Alignment is how labels line up in their column.
+type Alignment int
+
+const (
+	// AlignLeft lines labels up on their left, which is the default.
+	AlignLeft Alignment = iota
+	// AlignRight lines labels up on their right, next to the bars.
+	AlignRight
+)
+
+// AlignLabels lines the labels of the buckets up according to a.
+func AlignLabels(a Alignment) Option {
+	return func(o *options) { o.align = a }
+}
+
+// RangeStyle is how the range of a bucket is labelled.
+type RangeStyle int
+
+const (
+	// DashRanges labels buckets like `0.1-0.2`, or `<0.1` and `>0.2`
+	// for underflow and overflow buckets. It's the default.
+	DashRanges RangeStyle = iota
+	// IntervalRanges labels buckets like `[0.1, 0.2)`, and like
+	// `(-∞, 0.1)` for underflow and overflow buckets. The last bucket
+	// of the histograms of Hist, HistEdges and Recorder, which
+	// includes its high bound, gets a closed bracket.
+	IntervalRanges
+)
+
+// RangeLabels labels the range of the buckets with style.
+func RangeLabels(style RangeStyle) Option {
+	return func(o *options) { o.ranges = style }
+}
+
+// RangeSeparator separates the bounds of the buckets with sep instead
+// of a dash, in the DashRanges style.
+func RangeSeparator(sep string) Option {
+	return func(o *options) { o.separator = sep }
+}
+
+// Padding puts n spaces between columns instead of 2.
+func Padding(n int) Option {
+	return func(o *options) {
+		n = imax(n, 0)
+		o.padding = &n
+	}
+}
+
+// EmptyMarker draws empty buckets with marker instead of a bar, such
+// as "-" or "".
+func EmptyMarker(marker string) Option {
+	return func(o *options) { o.empty = &marker }
+}
+
+// GlyphSet lists the glyphs that bars are drawn with, from the thinnest
+// to the full glyph. A bar is drawn with full glyphs, followed by a
+// glyph as thick as the remaining fraction of the bar. Glyph sets are
+// shared with the barchart package.
+type GlyphSet = bars.Set
+
+var (
+	// Blocks draws bars with eighths of Unicode blocks, `▏▎▍▌▋▊▉█`.
+	// It's the default.
+	Blocks = bars.Blocks
+	// ASCII draws bars with ASCII characters, `.-=#`, for terminals
+	// and fonts that lack Unicode blocks.
+	ASCII = bars.ASCII
+)
+
+// Glyphs draws the bars of the buckets with g, which must have at
+// least one glyph.
+func Glyphs(g GlyphSet) Option {
+	return func(o *options) {
+		if len(g) > 0 {
+			o.glyphs = g
+		}
+	}
+}
+
+// bar draws the bar of a bucket of size sz holding count values.
+func (o options) bar(sz, count float64) string {
+	switch {
+	case count == 0 && o.empty != nil:
+		return *o.empty
+	case o.glyphs != nil:
+		return o.glyphs.Bar(sz)
+	}
+	return barstring(sz)
+}
+
+// label names the range of the bucket at i of h, in the style asked
+// for.
+func (o options) label(h Histogram, i int, f FormatFunc) string {
+	bkt := h.Buckets[i]
+	if o.ranges == DashRanges && o.separator == "" {
+		return label(bkt, f)
+	}
+	if o.ranges == DashRanges {
+		if bkt.Min == bkt.Max || math.IsInf(bkt.Min, 0) || math.IsInf(bkt.Max, 0) {
+			return label(bkt, f)
+		}
+		return f(bkt.Min) + o.separator + f(bkt.Max)
+	}
+
+	lo, hi := "[", ")"
+	min, max := f(bkt.Min), f(bkt.Max)
+	if math.IsInf(bkt.Min, -1) {
+		lo, min = "(", "-∞"
+	}
+	if math.IsInf(bkt.Max, 1) {
+		// the overflow bucket holds the values above the last
+		// bound, which the bucket before it includes
+		lo, max = "(", "+∞"
+	}
+	if bkt.Min == bkt.Max {
+		return f(bkt.Min)
+	}
+	if i == lastFinite(h) && !h.halfOpen {
+		hi = "]"
+	}
+	return lo + min + ", " + max + hi
+}
+
+// lastFinite gives the index of the last bucket of h whose high bound
+// is finite.
+func lastFinite(h Histogram) int {
+	i := len(h.Buckets) - 1
+	for i >= 0 && math.IsInf(h.Buckets[i].Max, 1) {
+		i--
+	}
+	return i
+}
+
+// alignLabels pads labels so that they line up as asked for.
+func (o options) alignLabels(labels []string) []string {
+	if o.align != AlignRight {
+		return labels
+	}
+	width := 0
+	for _, l := range labels {
+		width = imax(width, utf8.RuneCountInString(l))
+	}
+	aligned := make([]string, len(labels))
+	for i, l := range labels {
+		aligned[i] = strings.Repeat(" ", width-utf8.RuneCountInString(l)) + l
+	}
+	return aligned
+}
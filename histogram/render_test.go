@@ -0,0 +1,143 @@
+package histogram
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func ExampleRender() {
+	rec := NewRecorder(LinearLayout(4, 0, 100))
+	for _, v := range []float64{5, 10, 20, 30, 35, 40, 90, 100, 120} {
+		rec.Record(v)
+	}
+	err := Render(os.Stdout, rec.Snapshot(),
+		WithScale(Linear(8)),
+		RangeLabels(IntervalRanges),
+		AlignLabels(AlignRight),
+		HideColumns(PercentColumn),
+		EmptyMarker("-"),
+		Glyphs(ASCII),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	//   (-∞, 0)  -
+	//   [0, 25)  ########.  3
+	//  [25, 50)  ########.  3
+	//  [50, 75)  -
+	// [75, 100]  #####-     2
+	// (100, +∞)  ##=        1
+}
+
+func ExampleRangeSeparator() {
+	h := Hist(3, []float64{1, 2, 2, 3, 3, 3, 4})
+	err := Fprint(os.Stdout, h, Linear(3), RangeSeparator(" to "), Padding(1), HideColumns(CountColumn))
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1 to 2 14.3% ▊
+	// 2 to 3 28.6% █▋
+	// 3 to 4 57.1% ███▏
+}
+
+func ExampleRangeLabels() {
+	h := PowerHist(10, []float64{2, 5, 20, 50, 100, math.NaN(), math.Inf(1)})
+	err := Render(os.Stdout, h,
+		WithScale(Linear(4)),
+		RangeLabels(IntervalRanges),
+		HideColumns(CountColumn),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// [1, 10)      40%  ████▏
+	// [10, 100)    40%  ████▏
+	// [100, 1000)  20%  ██▏
+	// NaN
+	// ±Inf
+}
+
+func ExampleRangeLabels_cumulative() {
+	h := Hist(2, []float64{0, 1, 2, 3, 4})
+	err := Render(os.Stdout, h,
+		WithScale(Linear(4)),
+		RangeLabels(IntervalRanges),
+		CumulativePercent(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// [0, 2)  40%   █▋     2
+	// [2, 4]  100%  ████▏  5
+}
+
+func TestRangeLabelsLastBucket(t *testing.T) {
+	h := Hist(2, []float64{0, 1, 2})
+	var viaJSON, viaCSV, viaBinary Histogram
+	data, _ := json.Marshal(h)
+	if err := json.Unmarshal(data, &viaJSON); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, h); err != nil {
+		t.Fatal(err)
+	}
+	viaCSV, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ = h.MarshalBinary()
+	if err := viaBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	merged, _ := Merge(h, h)
+
+	power := PowerHist(10, []float64{2, 20, 200})
+	var powerJSON Histogram
+	data, _ = json.Marshal(power)
+	if err := json.Unmarshal(data, &powerJSON); err != nil {
+		t.Fatal(err)
+	}
+	powerMerged, _ := Merge(Histogram{}, power)
+
+	rec := NewRecorder(LinearLayout(4, 0, 4))
+	rec.Record(0.5)
+	rec.Record(1.5)
+
+	for _, tt := range []struct {
+		name string
+		h    Histogram
+		opts []Option
+		want []string
+	}{
+		{"Hist", h, nil, []string{"[1, 2]"}},
+		{"JSON", viaJSON, nil, []string{"[1, 2]"}},
+		{"CSV", viaCSV, nil, []string{"[1, 2]"}},
+		{"binary", viaBinary, nil, []string{"[1, 2]"}},
+		{"Merge", merged, nil, []string{"[1, 2]"}},
+		{"PowerHist", power, nil, []string{"[100, 1000)"}},
+		{"PowerHist JSON", powerJSON, nil, []string{"[100, 1000)"}},
+		{"PowerHist Merge", powerMerged, nil, []string{"[100, 1000)"}},
+		{"HistTrimmed", HistTrimmed(2, TrimIQR(1.5), []float64{1, 2, 2, 3, 100}), nil, []string{"[2, 3]", "(3, +∞)"}},
+		{"TrimEmpty", rec.Snapshot(), []Option{TrimEmpty()}, []string{"[1, 2)"}},
+	} {
+		var out bytes.Buffer
+		opts := append([]Option{RangeLabels(IntervalRanges)}, tt.opts...)
+		if err := Render(&out, tt.h, opts...); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out.String(), want+" ") {
+				t.Errorf("%s: want a label %s, got\n%s", tt.name, want, out.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package histogram
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// Log builds a ScaleFunc that scales the values of an histogram on a
+// log(1+x) axis, so that small buckets stay visible next to huge
+// ones. Values do not exceed width.
+func Log(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		if min == max {
+			return 1
+		}
+		return math.Log1p(value-min) / math.Log1p(max-min) * float64(width)
+	}
+}
+
+// Sqrt builds a ScaleFunc that scales the values of an histogram on
+// a square root axis, so that they do not exceed width.
+func Sqrt(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		if min == max {
+			return 1
+		}
+		return math.Sqrt(value-min) / math.Sqrt(max-min) * float64(width)
+	}
+}
+
+// SymLog builds a ScaleFunc that scales the values of an histogram on
+// a symmetric log axis, sign(x)·log(1+|x|), which is defined for
+// negative values too. Values do not exceed width.
+func SymLog(width int) ScaleFunc {
+	return func(min, max, value float64) float64 {
+		if min == max {
+			return 1
+		}
+		lo, hi := symlog(min), symlog(max)
+		return (symlog(value) - lo) / (hi - lo) * float64(width)
+	}
+}
+
+func symlog(x float64) float64 {
+	if x < 0 {
+		return -math.Log1p(-x)
+	}
+	return math.Log1p(x)
+}
+
+// Clamp builds a ScaleFunc that scales the values of an histogram
+// linearly, up to the given percentile of the counts of the non-empty
+// buckets of h, in [0, 100]. Buckets above that count are drawn at
+// full width, so that a single huge bucket doesn't squash all the
+// others. Clamp panics on percentiles outside of [0, 100].
+func Clamp(width int, percentile float64, h Histogram) ScaleFunc {
+	if !(percentile >= 0 && percentile <= 100) {
+		log.Panicf("histogram: invalid clamp, percentile=%f", percentile)
+	}
+	var counts []float64
+	for _, bkt := range h.Buckets {
+		if bkt.Count > 0 {
+			counts = append(counts, bkt.Count)
+		}
+	}
+	sort.Float64s(counts)
+	ceil := quantileSorted(counts, percentile/100)
+	linear := Linear(width)
+	return func(min, max, value float64) float64 {
+		if len(counts) == 0 || ceil <= min {
+			return linear(min, max, value)
+		}
+		return linear(min, ceil, math.Min(value, ceil))
+	}
+}
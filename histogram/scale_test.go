@@ -0,0 +1,74 @@
+package histogram
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+var skewed = func() []float64 {
+	data := make([]float64, 0, 10010)
+	for i := 0; i < 10000; i++ {
+		data = append(data, 1)
+	}
+	return append(data, 2, 2, 2, 3, 3, 4, 5, 5, 5, 6)
+}()
+
+func ExampleLog() {
+	hist := Hist(6, skewed)
+
+	if err := Fprint(os.Stdout, hist, Log(10), ScaleLegend("log")); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1-1.833      99.9%     ██████████▏  10000
+	// 1.833-2.667  0.03%     █▋           3
+	// 2.667-3.5    0.02%     █▏           2
+	// 3.5-4.333    0.00999%  ▊            1
+	// 4.333-5.167  0.03%     █▋           3
+	// 5.167-6      0.00999%  ▊            1
+	// scale: log
+}
+
+func ExampleClamp() {
+	hist := Hist(6, skewed)
+
+	if err := Fprint(os.Stdout, hist, Clamp(10, 80, hist), ScaleLegend("linear, clamped at p80")); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1-1.833      99.9%     ██████████▏  10000
+	// 1.833-2.667  0.03%     ██████████▏  3
+	// 2.667-3.5    0.02%     ██████▋      2
+	// 3.5-4.333    0.00999%  ███▍         1
+	// 4.333-5.167  0.03%     ██████████▏  3
+	// 5.167-6      0.00999%  ███▍         1
+	// scale: linear, clamped at p80
+}
+
+func ExampleScaleLegend() {
+	hist := Hist(2, []float64{1, 2, 2, 2})
+
+	square := func(min, max, value float64) float64 { return value * value / 4 }
+	if err := Fprint(os.Stdout, hist, square, ScaleLegend("square")); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1-1.5  25%  ▎    1
+	// 1.5-2  75%  ██▎  3
+	// scale: square
+}
+
+func TestClampInvalidPercentile(t *testing.T) {
+	hist := Hist(2, []float64{1, 2, 2, 2})
+	for _, percentile := range []float64{-1, 150, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("percentile=%v: want a panic", percentile)
+				}
+			}()
+			Clamp(10, percentile, hist)
+		}()
+	}
+}
@@ -0,0 +1,180 @@
+package histogram
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ShardedRecorder counts values over the buckets of a Layout like a
+// Recorder, but is safe for concurrent use. It's meant for hot paths
+// recording from many goroutines: counts are spread over shards to
+// avoid contention, and recording a value takes no lock and allocates
+// nothing.
+type ShardedRecorder struct {
+	layout Layout
+	// lo and scale turn a value of each finite bucket into the
+	// fixed-point offset summed for it
+	lo, scale []float64
+	shards    []recorderShard
+	// mu serializes snapshots, records don't take it
+	mu sync.Mutex
+}
+
+// recorderShard counts the values recorded on a shard in two halves.
+// Records go to the hot half, while a snapshot reads the cold half
+// once all the records that went to it are done, so that it sees no
+// half done record.
+type recorderShard struct {
+	_ [cacheLinePad]byte
+	// started counts the records started on the shard in its low
+	// 63 bits, and its top bit tells which half is hot
+	started atomic.Uint64
+	halves  [2]shardHalf
+	_       [cacheLinePad]byte
+}
+
+// shardHalf counts values in each bucket, and sums them as fixed-point
+// offsets from the low bound of their bucket, which an atomic add
+// keeps exact. The sum of the values of the underflow and overflow
+// buckets, which aren't bounded, is kept as the bits of a float64.
+type shardHalf struct {
+	counts  []atomic.Uint64
+	offsets []atomic.Uint64
+	nan     atomic.Uint64
+	open    atomic.Uint64
+}
+
+const (
+	hotBit = 1 << 63
+	// cacheLinePad keeps shards on cache lines of their own, twice
+	// as long as usual as some processors fetch lines in pairs
+	cacheLinePad = 128
+	// offsetUnits splits the width of a bucket in fixed-point units.
+	// A bucket can sum the offsets of 2^40 values before overflowing.
+	offsetUnits = 1 << 24
+)
+
+// NewShardedRecorder creates a ShardedRecorder counting values over l,
+// with as many shards as processors that Go code runs on.
+func NewShardedRecorder(l Layout) *ShardedRecorder {
+	n := len(l.edges) + 1
+	r := &ShardedRecorder{
+		layout: l,
+		lo:     make([]float64, n),
+		scale:  make([]float64, n),
+		shards: make([]recorderShard, runtime.GOMAXPROCS(0)),
+	}
+	for i := 1; i < len(l.edges); i++ {
+		r.lo[i] = l.edges[i-1]
+		r.scale[i] = offsetUnits / (l.edges[i] - l.edges[i-1])
+	}
+	for i := range r.shards {
+		// a block per shard, padded so that shards don't share the
+		// cache lines of their counts
+		block := make([]atomic.Uint64, 4*n+cacheLinePad/8)
+		for j := range r.shards[i].halves {
+			half := &r.shards[i].halves[j]
+			half.counts = block[2*j*n : (2*j+1)*n]
+			half.offsets = block[(2*j+1)*n : (2*j+2)*n]
+		}
+	}
+	return r
+}
+
+// Record counts v in its bucket. NaN values are counted apart.
+//
+// A goroutine records on a shard picked from the address of its
+// stack, so that it tends to stick to the same shard while goroutines
+// spread over all of them.
+func (r *ShardedRecorder) Record(v float64) {
+	var anchor byte
+	slot := uint64(uint32(uintptr(unsafe.Pointer(&anchor))>>10) * 0x9e3779b1)
+	s := &r.shards[slot*uint64(len(r.shards))>>32]
+	half := &s.halves[s.started.Add(1)>>63]
+	if math.IsNaN(v) {
+		half.nan.Add(1)
+		return
+	}
+	// the count goes last: a snapshot waits on the counts to know
+	// that the records of a half are done
+	idx := r.layout.index(v)
+	switch {
+	case idx > 0 && idx < len(r.layout.edges):
+		half.offsets[idx].Add(uint64(math.Round((v - r.lo[idx]) * r.scale[idx])))
+	case !math.IsInf(v, 0):
+		addFloat(&half.open, v)
+	}
+	half.counts[idx].Add(1)
+}
+
+// Snapshot creates an histogram of the values recorded so far. Values
+// recorded while the snapshot is taken may be left out, but each of
+// them is either counted in full or not at all. The first and last
+// buckets of the histogram are the underflow and overflow buckets.
+func (r *ShardedRecorder) Snapshot() Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buckets := r.layout.buckets()
+	offsets := make([]uint64, len(buckets))
+	var sum float64
+	var nan int
+	for i := range r.shards {
+		s := &r.shards[i]
+		// swap the halves, then wait for the records that went
+		// to the now cold half
+		started := s.started.Add(hotBit)
+		hot, cold := &s.halves[started>>63], &s.halves[(started>>63)^1]
+		for cold.done() != started&^hotBit {
+			runtime.Gosched()
+		}
+
+		// read the cold half and fold it in the hot one, so that
+		// the next snapshot sees its counts
+		for j := range cold.counts {
+			c := cold.counts[j].Swap(0)
+			buckets[j].Count += float64(c)
+			hot.counts[j].Add(c)
+			off := cold.offsets[j].Swap(0)
+			offsets[j] += off
+			hot.offsets[j].Add(off)
+		}
+		open := math.Float64frombits(cold.open.Swap(0))
+		sum += open
+		addFloat(&hot.open, open)
+		coldNaN := cold.nan.Swap(0)
+		nan += int(coldNaN)
+		hot.nan.Add(coldNaN)
+	}
+	for j := 1; j < len(r.layout.edges); j++ {
+		sum += buckets[j].Count*r.lo[j] + float64(offsets[j])/r.scale[j]
+	}
+
+	h := newHistogram(buckets)
+	h.Sum = sum
+	h.NaN = nan
+	return h
+}
+
+// done counts the records that are done in the half, including those
+// folded in it by previous snapshots.
+func (h *shardHalf) done() uint64 {
+	n := h.nan.Load()
+	for i := range h.counts {
+		n += h.counts[i].Load()
+	}
+	return n
+}
+
+// addFloat atomically adds v to the float64 whose bits are in x.
+func addFloat(x *atomic.Uint64, v float64) {
+	for {
+		old := x.Load()
+		if x.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func ExampleNewShardedRecorder() {
+	rec := NewShardedRecorder(LinearLayout(4, 0, 100))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				rec.Record(float64(i % 100))
+			}
+		}()
+	}
+	wg.Wait()
+
+	h := rec.Snapshot()
+	if err := Fprint(os.Stdout, h, Linear(5)); err != nil {
+		panic(err)
+	}
+	fmt.Printf("sum: %.0f\n", h.Sum)
+	// Output:
+	// <0      0%   ▏
+	// 0-25    25%  █████▏  2000
+	// 25-50   25%  █████▏  2000
+	// 50-75   25%  █████▏  2000
+	// 75-100  25%  █████▏  2000
+	// >100    0%   ▏
+	// sum: 396000
+}
+
+// latency gives the i-th of a stream of fake latencies, in ms.
+func latency(i int) float64 { return float64(i%1000) / 10 }
+
+func BenchmarkShardedRecorder(b *testing.B) {
+	rec := NewShardedRecorder(LinearLayout(20, 0, 100))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			rec.Record(latency(i))
+		}
+	})
+	rec.Snapshot()
+}
+
+func BenchmarkShardedRecorderSnapshot(b *testing.B) {
+	rec := NewShardedRecorder(LinearLayout(20, 0, 100))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%1000 == 0 {
+				rec.Snapshot()
+			} else {
+				rec.Record(latency(i))
+			}
+		}
+	})
+}
+
+func BenchmarkMutexRecorder(b *testing.B) {
+	rec := NewRecorder(LinearLayout(20, 0, 100))
+	var mu sync.Mutex
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			mu.Lock()
+			rec.Record(latency(i))
+			mu.Unlock()
+		}
+	})
+	rec.Snapshot()
+}
+
+func BenchmarkMutexHist(b *testing.B) {
+	var (
+		mu     sync.Mutex
+		values []float64
+	)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			mu.Lock()
+			values = append(values, latency(i))
+			mu.Unlock()
+		}
+	})
+	Hist(20, values)
+}
@@ -0,0 +1,339 @@
+package histogram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"log"
+	"math"
+)
+
+// ErrSketchMismatch is returned when merging sketches of different
+// relative accuracies.
+var ErrSketchMismatch = errors.New("histogram: sketches have different accuracies")
+
+// Sketch estimates the quantiles of a stream of values in bounded
+// memory, with a relative error guarantee. It's a DDSketch: values are
+// counted in buckets whose bounds grow geometrically, so that any
+// value of a bucket is within the relative accuracy of the value the
+// bucket stands for.
+//
+// When a sketch would exceed its maximum number of buckets, its
+// buckets closest to zero are collapsed together, which only loses
+// accuracy on the values of the smallest magnitudes.
+//
+// A Sketch is not safe for concurrent use.
+type Sketch struct {
+	alpha    float64
+	gamma    float64
+	logGamma float64
+	maxBins  int
+
+	// positive values, and the absolute negative values
+	pos, neg sketchStore
+	zeros    float64
+
+	count    float64
+	sum      float64
+	min, max float64
+	nan, inf int
+}
+
+// sketchStore counts values in the buckets of consecutive indices,
+// counts[i] counting values of the bucket at index offset+i.
+type sketchStore struct {
+	offset int
+	counts []float64
+}
+
+// NewSketch creates a Sketch estimating quantiles within a relative
+// accuracy in (0, 1), such as 0.01 for 1%, over at most maxBins
+// buckets for positive values and as many for negative values.
+func NewSketch(relativeAccuracy float64, maxBins int) *Sketch {
+	if !(relativeAccuracy > 0 && relativeAccuracy < 1) || maxBins <= 0 {
+		log.Panicf("histogram: invalid sketch, relativeAccuracy=%f\tmaxBins=%d", relativeAccuracy, maxBins)
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &Sketch{
+		alpha:    relativeAccuracy,
+		gamma:    gamma,
+		logGamma: math.Log(gamma),
+		maxBins:  maxBins,
+		min:      math.Inf(1),
+		max:      math.Inf(-1),
+	}
+}
+
+// Add counts v in the sketch. NaN and infinite values are counted
+// apart.
+func (s *Sketch) Add(v float64) {
+	switch {
+	case math.IsNaN(v):
+		s.nan++
+		return
+	case math.IsInf(v, 0):
+		s.inf++
+		return
+	case v > 0:
+		s.pos.add(s.index(v), 1, s.maxBins)
+	case v < 0:
+		s.neg.add(s.index(-v), 1, s.maxBins)
+	default:
+		s.zeros++
+	}
+	s.count++
+	s.sum += v
+	s.min = math.Min(s.min, v)
+	s.max = math.Max(s.max, v)
+}
+
+// index gives the index of the bucket holding the positive value v,
+// which holds the values in (γ^(index-1), γ^index].
+func (s *Sketch) index(v float64) int {
+	return int(math.Ceil(math.Log(v) / s.logGamma))
+}
+
+// value gives the value that the bucket at index stands for, which is
+// within the relative accuracy of any value of the bucket.
+func (s *Sketch) value(index int) float64 {
+	return 2 * math.Pow(s.gamma, float64(index)) / (s.gamma + 1)
+}
+
+// Count gives how many finite values were added to the sketch.
+func (s *Sketch) Count() float64 { return s.count }
+
+// Merge adds the counts of o to s, which must have the same relative
+// accuracy.
+func (s *Sketch) Merge(o *Sketch) error {
+	if s.gamma != o.gamma {
+		return ErrSketchMismatch
+	}
+	for i, c := range o.pos.counts {
+		s.pos.add(o.pos.offset+i, c, s.maxBins)
+	}
+	for i, c := range o.neg.counts {
+		s.neg.add(o.neg.offset+i, c, s.maxBins)
+	}
+	s.zeros += o.zeros
+	s.count += o.count
+	s.sum += o.sum
+	s.min = math.Min(s.min, o.min)
+	s.max = math.Max(s.max, o.max)
+	s.nan += o.nan
+	s.inf += o.inf
+	return nil
+}
+
+// Quantile estimates the q-th quantile of the values added to the
+// sketch, q being in [0, 1]. The estimate is within the relative
+// accuracy of the sketch, unless it fell in collapsed buckets. It's
+// NaN if the sketch is empty.
+func (s *Sketch) Quantile(q float64) float64 {
+	if s.count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	rank := q * (s.count - 1)
+
+	var estimate float64
+	seen := 0.0
+	found := false
+	for i := len(s.neg.counts) - 1; i >= 0 && !found; i-- {
+		seen += s.neg.counts[i]
+		if seen > rank {
+			estimate, found = -s.value(s.neg.offset+i), true
+		}
+	}
+	if !found {
+		seen += s.zeros
+		found = seen > rank
+	}
+	for i := 0; i < len(s.pos.counts) && !found; i++ {
+		seen += s.pos.counts[i]
+		if seen > rank {
+			estimate, found = s.value(s.pos.offset+i), true
+		}
+	}
+	if !found {
+		return s.max
+	}
+	// the extreme buckets stand for values past the extremes
+	return math.Max(s.min, math.Min(s.max, estimate))
+}
+
+// Histogram creates an histogram of the values of the sketch, over
+// about `bins` buckets. Each bucket of the histogram groups
+// consecutive buckets of the sketch, so that no count is
+// interpolated. If bins isn't positive, each bucket of the sketch
+// becomes a bucket of the histogram.
+func (s *Sketch) Histogram(bins int) Histogram {
+	var buckets []Bucket
+	for i := len(s.neg.counts) - 1; i >= 0; i-- {
+		idx := s.neg.offset + i
+		buckets = append(buckets, Bucket{
+			Count: s.neg.counts[i],
+			Min:   -math.Pow(s.gamma, float64(idx)),
+			Max:   -math.Pow(s.gamma, float64(idx-1)),
+		})
+	}
+	if s.zeros > 0 {
+		buckets = append(buckets, Bucket{Count: s.zeros})
+	}
+	for i, c := range s.pos.counts {
+		idx := s.pos.offset + i
+		buckets = append(buckets, Bucket{
+			Count: c,
+			Min:   math.Pow(s.gamma, float64(idx-1)),
+			Max:   math.Pow(s.gamma, float64(idx)),
+		})
+	}
+	if n := len(buckets); n > 0 {
+		// the extreme buckets need not reach past the extremes
+		buckets[0].Min = math.Max(buckets[0].Min, s.min)
+		buckets[n-1].Max = math.Min(buckets[n-1].Max, s.max)
+	}
+
+	if bins > 0 && len(buckets) > bins {
+		group := (len(buckets) + bins - 1) / bins
+		grouped := make([]Bucket, 0, bins)
+		for i := 0; i < len(buckets); i += group {
+			last := imin(i+group, len(buckets)) - 1
+			bkt := Bucket{Min: buckets[i].Min, Max: buckets[last].Max}
+			for _, b := range buckets[i : last+1] {
+				bkt.Count += b.Count
+			}
+			grouped = append(grouped, bkt)
+		}
+		buckets = grouped
+	}
+
+	h := newHistogram(buckets)
+	h.Sum = s.sum
+	h.NaN = s.nan
+	h.Inf = s.inf
+	return h
+}
+
+// add counts c values in the bucket at index, collapsing the lowest
+// buckets if there would be more than maxBins of them.
+func (st *sketchStore) add(index int, c float64, maxBins int) {
+	if len(st.counts) == 0 {
+		st.offset = index
+		st.counts = []float64{0}
+	}
+	lo := imin(st.offset, index)
+	hi := imax(st.offset+len(st.counts)-1, index)
+	if floor := hi - maxBins + 1; lo < floor {
+		lo, index = floor, imax(index, floor)
+	}
+
+	if lo != st.offset || hi != st.offset+len(st.counts)-1 {
+		counts := make([]float64, hi-lo+1)
+		for i, c := range st.counts {
+			// buckets below lo collapse into it
+			counts[imax(st.offset+i, lo)-lo] += c
+		}
+		st.counts, st.offset = counts, lo
+	}
+	st.counts[index-st.offset] += c
+}
+
+// sketchMagic starts the binary encoding of a Sketch.
+const sketchMagic = "UPS"
+
+// MarshalBinary encodes s in a compact binary form, versioned like
+// the encoding of an Histogram.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString(sketchMagic)
+	buf.WriteByte(codecVersion)
+
+	var scratch [binary.MaxVarintLen64]byte
+	putFloat := func(v float64) {
+		binary.LittleEndian.PutUint64(scratch[:8], math.Float64bits(v))
+		buf.Write(scratch[:8])
+	}
+	putVarint := func(v int) {
+		buf.Write(scratch[:binary.PutVarint(scratch[:], int64(v))])
+	}
+	putStore := func(st sketchStore) {
+		putVarint(st.offset)
+		putVarint(len(st.counts))
+		for _, c := range st.counts {
+			putFloat(c)
+		}
+	}
+
+	putFloat(s.alpha)
+	putVarint(s.maxBins)
+	putFloat(s.zeros)
+	putFloat(s.count)
+	putFloat(s.sum)
+	putFloat(s.min)
+	putFloat(s.max)
+	putVarint(s.nan)
+	putVarint(s.inf)
+	putStore(s.pos)
+	putStore(s.neg)
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < len(sketchMagic)+1 || string(data[:len(sketchMagic)]) != sketchMagic {
+		return ErrInvalidEncoding
+	}
+	if err := checkVersion(int(data[len(sketchMagic)])); err != nil {
+		return err
+	}
+	r := bytes.NewReader(data[len(sketchMagic)+1:])
+
+	var bad bool
+	getFloat := func() float64 {
+		var bits uint64
+		if err := binary.Read(r, binary.LittleEndian, &bits); err != nil {
+			bad = true
+		}
+		return math.Float64frombits(bits)
+	}
+	getVarint := func() int {
+		v, err := binary.ReadVarint(r)
+		if err != nil || v < math.MinInt32 || v > math.MaxInt32 {
+			bad = true
+		}
+		return int(v)
+	}
+	getStore := func() sketchStore {
+		st := sketchStore{offset: getVarint()}
+		n := getVarint()
+		if bad || n < 0 || n > r.Len()/8 {
+			bad = true
+			return st
+		}
+		st.counts = make([]float64, n)
+		for i := range st.counts {
+			st.counts[i] = getFloat()
+		}
+		return st
+	}
+
+	alpha := getFloat()
+	maxBins := getVarint()
+	if bad || !(alpha > 0 && alpha < 1) || maxBins <= 0 {
+		return ErrInvalidEncoding
+	}
+	decoded := NewSketch(alpha, maxBins)
+	decoded.zeros = getFloat()
+	decoded.count = getFloat()
+	decoded.sum = getFloat()
+	decoded.min = getFloat()
+	decoded.max = getFloat()
+	decoded.nan = getVarint()
+	decoded.inf = getVarint()
+	decoded.pos = getStore()
+	decoded.neg = getStore()
+	if bad || r.Len() > 0 {
+		return ErrInvalidEncoding
+	}
+	*s = *decoded
+	return nil
+}
@@ -0,0 +1,88 @@
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+func ExampleSketch() {
+	r := rand.New(rand.NewSource(7))
+	sketch := NewSketch(0.01, 2048)
+	var values []float64
+	for i := 0; i < 100000; i++ {
+		// latencies in ms, with a long tail
+		v := 10 * math.Exp(r.NormFloat64())
+		sketch.Add(v)
+		values = append(values, v)
+	}
+
+	sorted := sortedCopy(values)
+	for _, q := range []float64{0.5, 0.99, 0.999} {
+		exact := quantileSorted(sorted, q)
+		estimate := sketch.Quantile(q)
+		fmt.Printf("p%g: %.4g, error %.2f%%\n", q*100, estimate, math.Abs(estimate-exact)/exact*100)
+	}
+	// Output:
+	// p50: 10.07, error 0.97%
+	// p99: 102.5, error 0.20%
+	// p99.9: 219.2, error 0.88%
+}
+
+func ExampleSketch_Histogram() {
+	sketch := NewSketch(0.02, 512)
+	for i := 1; i <= 1000; i++ {
+		sketch.Add(float64(i))
+	}
+	f := func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	if err := Fprintf(os.Stdout, sketch.Histogram(6), Linear(10), f); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1-3       0.3%   ▏            3
+	// 3-10      0.6%   ▏            6
+	// 10-31     2.2%   ▍            22
+	// 31-100    6.8%   █            68
+	// 100-318   21.8%  ███▏         218
+	// 318-1000  68.3%  ██████████▏  683
+}
+
+func ExampleSketch_Merge() {
+	a, b := NewSketch(0.01, 1024), NewSketch(0.01, 1024)
+	for i := 1; i <= 100; i++ {
+		a.Add(float64(i))
+		b.Add(float64(-i))
+	}
+	if err := a.Merge(b); err != nil {
+		panic(err)
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	var decoded Sketch
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	fmt.Printf("%g values, min %.4g, p25 %.4g, max %.4g\n",
+		decoded.Count(), decoded.Quantile(0), decoded.Quantile(0.25), decoded.Quantile(1))
+	// Output:
+	// 200 values, min -100, p25 -50.91, max 100
+}
+
+func ExampleSketch_UnmarshalBinary_invalid() {
+	data, err := NewSketch(0.01, 64).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	var s Sketch
+	for _, version := range []byte{0, codecVersion + 1} {
+		data[len(sketchMagic)] = version
+		fmt.Println(s.UnmarshalBinary(data))
+	}
+	// Output:
+	// histogram: invalid encoding
+	// histogram: unsupported encoding version
+}
@@ -0,0 +1,78 @@
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+)
+
+// Summary holds statistics about a set of values.
+type Summary struct {
+	// Count is the number of values, NaN excluded.
+	Count int
+	// Min and Max are the smallest and biggest values.
+	Min, Max float64
+	// Mean and StdDev are the mean of the values and their
+	// standard deviation.
+	Mean, StdDev float64
+	// Median and P99 are the 50th and 99th percentiles of the
+	// values.
+	Median, P99 float64
+}
+
+// Summarize computes statistics about input, ignoring NaN values.
+func Summarize(input []float64) Summary {
+	sorted := sortedCopy(input)
+	// NaNs are sorted first
+	for len(sorted) > 0 && math.IsNaN(sorted[0]) {
+		sorted = sorted[1:]
+	}
+	if len(sorted) == 0 {
+		return Summary{}
+	}
+	return Summary{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean(sorted),
+		StdDev: stddev(sorted),
+		Median: quantileSorted(sorted, 0.5),
+		P99:    quantileSorted(sorted, 0.99),
+	}
+}
+
+// WithSummary prints s under the chart, with values formatted like
+// the axis labels:
+//
+//	count   9
+//	min     100ms
+//	max     1s
+//	mean    462ms
+//	stddev  300ms
+//	median  500ms
+//	p99     992ms
+func WithSummary(s Summary) Option {
+	return func(o *options) { o.summary = &s }
+}
+
+// summaryBlock gives the lines printing the summary, if any.
+func (o options) summaryBlock(f FormatFunc) string {
+	if o.summary == nil {
+		return ""
+	}
+	s := o.summary
+	buf := bytes.NewBuffer(nil)
+	tabw := tabwriter.NewWriter(buf, 2, 2, 2, byte(' '), 0)
+	fmt.Fprintf(tabw, "count\t%d\n", s.Count)
+	if s.Count > 0 {
+		fmt.Fprintf(tabw, "min\t%s\n", f(s.Min))
+		fmt.Fprintf(tabw, "max\t%s\n", f(s.Max))
+		fmt.Fprintf(tabw, "mean\t%s\n", f(s.Mean))
+		fmt.Fprintf(tabw, "stddev\t%s\n", f(s.StdDev))
+		fmt.Fprintf(tabw, "median\t%s\n", f(s.Median))
+		fmt.Fprintf(tabw, "p99\t%s\n", f(s.P99))
+	}
+	_ = tabw.Flush()
+	return buf.String()
+}
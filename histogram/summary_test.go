@@ -0,0 +1,48 @@
+package histogram
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+func ExampleSummarize() {
+	s := Summarize(latencies)
+	fmt.Printf("mean=%.4g median=%.4g p99=%.4g\n", s.Mean, s.Median, s.P99)
+	// Output:
+	// mean=26.3 median=18.5 p99=88.35
+}
+
+func ExampleWithSummary() {
+	data := []float64{
+		float64(time.Millisecond * 100),
+		float64(time.Millisecond * 200),
+		float64(time.Millisecond * 210),
+		float64(time.Millisecond * 220),
+		float64(time.Millisecond * 500),
+		float64(time.Millisecond * 510),
+		float64(time.Millisecond * 520),
+		float64(time.Millisecond * 900),
+		float64(time.Millisecond * 1000),
+	}
+
+	hist := Hist(3, data)
+	durations := func(v float64) string {
+		return time.Duration(v).Round(time.Millisecond).String()
+	}
+
+	if err := Fprintf(os.Stdout, hist, Linear(5), durations, WithSummary(Summarize(data))); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 100ms-400ms  44.4%  █████▏  4
+	// 400ms-700ms  33.3%  ███▊    3
+	// 700ms-1s     22.2%  ██▋     2
+	// count   9
+	// min     100ms
+	// max     1s
+	// mean    462ms
+	// stddev  300ms
+	// median  500ms
+	// p99     992ms
+}
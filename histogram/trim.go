@@ -0,0 +1,80 @@
+package histogram
+
+import "math"
+
+// Trim picks the range [lo, hi] of the values worth partitionning in
+// buckets, out of sorted values. Values out of that range are
+// outliers.
+type Trim func(sorted []float64) (lo, hi float64)
+
+// TrimPercentiles keeps the values between the lo-th and hi-th
+// percentiles, in [0, 100]. For instance, TrimPercentiles(1, 99)
+// keeps all but the 1% smallest and 1% biggest values.
+func TrimPercentiles(lo, hi float64) Trim {
+	return func(sorted []float64) (float64, float64) {
+		return quantileSorted(sorted, lo/100), quantileSorted(sorted, hi/100)
+	}
+}
+
+// TrimIQR keeps the values within k interquartile ranges of the first
+// and third quartiles. Tukey's fences use k = 1.5.
+func TrimIQR(k float64) Trim {
+	return func(sorted []float64) (float64, float64) {
+		q1, q3 := quantileSorted(sorted, 0.25), quantileSorted(sorted, 0.75)
+		iqr := q3 - q1
+		return q1 - k*iqr, q3 + k*iqr
+	}
+}
+
+// HistTrimmed creates an histogram partitionning the values of input
+// kept by trim over `bins` buckets. The outliers are counted in an
+// underflow and an overflow bucket, printed like `<lo` and `>hi`,
+// which are omitted if they are empty.
+func HistTrimmed(bins int, trim Trim, input []float64) Histogram {
+	sorted := sortedCopy(input)
+	nan := 0
+	// NaNs are sorted first
+	for len(sorted) > 0 && math.IsNaN(sorted[0]) {
+		sorted = sorted[1:]
+		nan++
+	}
+	if len(sorted) == 0 || bins == 0 {
+		return Histogram{NaN: nan}
+	}
+
+	lo, hi := trim(sorted)
+	var below, above float64
+	inner := make([]float64, 0, len(sorted))
+	for _, v := range sorted {
+		switch {
+		case v < lo, math.IsInf(v, -1):
+			below++
+		case v > hi, math.IsInf(v, 1):
+			above++
+		default:
+			inner = append(inner, v)
+		}
+	}
+	if len(inner) == 0 {
+		// nothing left to bucket, don't trim
+		h := Hist(bins, sorted)
+		h.NaN = nan
+		return h
+	}
+
+	h := Hist(bins, inner)
+	minIn, maxIn := inner[0], inner[len(inner)-1]
+	buckets := make([]Bucket, 0, len(h.Buckets)+2)
+	buckets = append(buckets, Bucket{Count: below, Min: math.Inf(-1), Max: minIn})
+	buckets = append(buckets, h.Buckets...)
+	buckets = append(buckets, Bucket{Count: above, Min: maxIn, Max: math.Inf(1)})
+
+	trimmed := newHistogram(trimOpen(buckets))
+	for _, v := range sorted {
+		if !math.IsInf(v, 0) {
+			trimmed.Sum += v
+		}
+	}
+	trimmed.NaN = nan
+	return trimmed
+}
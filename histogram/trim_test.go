@@ -0,0 +1,31 @@
+package histogram
+
+import (
+	"os"
+	"time"
+)
+
+func ExampleHistTrimmed() {
+	var data []float64
+	for i := 0; i < 40; i++ {
+		data = append(data, float64(time.Duration(3+i%5)*time.Millisecond))
+	}
+	// a single request timed out
+	data = append(data, float64(30*time.Second))
+
+	hist := HistTrimmed(5, TrimIQR(1.5), data)
+
+	err := Fprintf(os.Stdout, hist, Linear(8), func(v float64) string {
+		return time.Duration(v).String()
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 3ms-3.8ms    19.5%  ████████▏  8
+	// 3.8ms-4.6ms  19.5%  ████████▏  8
+	// 4.6ms-5.4ms  19.5%  ████████▏  8
+	// 5.4ms-6.2ms  19.5%  ████████▏  8
+	// 6.2ms-7ms    19.5%  ████████▏  8
+	// >7ms         2.44%  █▏         1
+}
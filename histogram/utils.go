@@ -1,6 +1,7 @@
 package histogram
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math"
@@ -41,35 +42,193 @@ var barstring = func(v float64) string {
 //	0.7-0.8  0%   ▏
 //	0.8-0.9  5%   ▋1
 //	0.9-1    10%  █▏2
-func Fprint(w io.Writer, h Histogram, s ScaleFunc) error {
-	return fprintf(w, h, s, func(v float64) string {
-		return fmt.Sprintf("%.4g", v)
-	})
+//
+// The opts customize the output, see Option.
+func Fprint(w io.Writer, h Histogram, s ScaleFunc, opts ...Option) error {
+	return Render(w, h, append([]Option{WithScale(s)}, opts...)...)
 }
 
 // Fprintf is the same as Fprint, but applies f to the axis labels.
-func Fprintf(w io.Writer, h Histogram, s ScaleFunc, f FormatFunc) error {
-	return fprintf(w, h, s, f)
+func Fprintf(w io.Writer, h Histogram, s ScaleFunc, f FormatFunc, opts ...Option) error {
+	return Render(w, h, append([]Option{WithScale(s), WithFormat(f)}, opts...)...)
+}
+
+func defaultFormat(v float64) string {
+	return fmt.Sprintf("%.4g", v)
 }
 
-func fprintf(w io.Writer, h Histogram, s ScaleFunc, f FormatFunc) error {
-	tabw := tabwriter.NewWriter(w, 2, 2, 2, byte(' '), 0)
+// formatCount prints counts of values, which have a fractional
+// part only in weighted histograms.
+func formatCount(c float64) string {
+	if c == math.Trunc(c) || math.Abs(c) >= 1000 {
+		return strconv.FormatFloat(c, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(c, 'g', 4, 64)
+}
 
-	yfmt := func(y int) string {
+func fprintf(w io.Writer, h Histogram, s ScaleFunc, f FormatFunc, o options) error {
+	legend := o.legendLine()
+	if width := o.lineWidth(w); width > 0 {
+		s = fitScale(h, s, f, o, width)
+	}
+	padding := 2
+	if o.padding != nil {
+		padding = *o.padding
+	}
+	buf := bytes.NewBuffer(nil)
+	tabw := tabwriter.NewWriter(buf, 0, 2, padding, byte(' '), 0)
+
+	yfmt := func(y float64) string {
 		if y > 0 {
-			return strconv.Itoa(y)
+			return formatCount(y)
 		}
 		return ""
 	}
+	// lines counts the lines written, for folds to be put back in
+	lines := 0
+	row := func(label, percent, bar, count, tail string) {
+		var cells []string
+		if o.shows(LabelColumn) {
+			cells = append(cells, label)
+		}
+		if o.shows(PercentColumn) {
+			cells = append(cells, percent)
+		}
+		cells = append(cells, bar)
+		if o.shows(CountColumn) {
+			cells = append(cells, count)
+		}
+		fmt.Fprint(tabw, strings.Join(cells, "\t")+tail+"\n")
+		lines++
+	}
+
+	h = o.trim(h)
+	marks := o.marks(h)
+	rugs := o.rugColumns(h)
+	if o.cumulative {
+		h = h.Cumulative()
+	}
+	// runs are folded on the counts printed, which are running counts
+	// with CumulativePercent
+	runs := o.fold(h)
+
+	// labels of the buckets, then of the NaN and ±Inf lines
+	labels := make([]string, len(h.Buckets), len(h.Buckets)+2)
+	for i := 0; i < len(h.Buckets); i++ {
+		if n := runs[i]; n > 0 {
+			i += n - 1
+			continue
+		}
+		labels[i] = o.label(h, i, f)
+	}
+	nan, inf := "", ""
+	if h.NaN > 0 {
+		nan = "NaN"
+	}
+	if h.Inf > 0 {
+		inf = "±Inf"
+	}
+	labels = o.alignLabels(append(labels, nan, inf))
+
+	folds := make(map[int]string)
+	for i := 0; i < len(h.Buckets); i++ {
+		if n := runs[i]; n > 0 {
+			// a line of empty cells keeps the columns aligned
+			// across the fold, whose text replaces it once the
+			// columns are laid out
+			span := Bucket{Min: h.Buckets[i].Min, Max: h.Buckets[i+n-1].Max}
+			folds[lines] = fmt.Sprintf("… %d empty buckets (%s) …", n, foldRange(span, f))
+			fmt.Fprint(tabw, "\t\t\t\t\t\t\n")
+			lines++
+			i += n - 1
+			continue
+		}
+		bkt := h.Buckets[i]
+		// an histogram holding no values, such as a snapshot taken
+		// before any value is recorded, has only empty bars
+		percent, size := "0%", 0.0
+		if h.Count > 0 {
+			percent = fmt.Sprintf("%.3g%%", bkt.Count*100.0/h.Count)
+			size = h.Scale(s, i)
+		}
+		row(labels[i], percent, o.bar(size, bkt.Count), yfmt(bkt.Count), rugs[i]+marks[i])
+	}
+
+	// NaN and infinite values are only told by their label and count
+	if o.shows(LabelColumn) || o.shows(CountColumn) {
+		if h.NaN > 0 {
+			row(labels[len(h.Buckets)], "", "", strconv.Itoa(h.NaN), "")
+		}
+		if h.Inf > 0 {
+			row(labels[len(h.Buckets)+1], "", "", strconv.Itoa(h.Inf), "")
+		}
+	}
 
-	for i, bkt := range h.Buckets {
-		sz := h.Scale(s, i)
-		fmt.Fprintf(tabw, "%s-%s\t%.3g%%\t%s\n",
-			f(bkt.Min), f(bkt.Max),
-			float64(bkt.Count)*100.0/float64(h.Count),
-			barstring(sz)+"\t"+yfmt(bkt.Count),
-		)
+	if err := tabw.Flush(); err != nil {
+		return err
 	}
+	if len(folds) > 0 {
+		buf = bytes.NewBuffer(fillFolds(buf.Bytes(), folds))
+	}
+	buf.WriteString(legend)
+	buf.WriteString(o.summaryBlock(f))
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// label names the range of bkt. Underflow and overflow buckets are
+// labelled by their finite bound only.
+func label(bkt Bucket, f FormatFunc) string {
+	switch {
+	case math.IsInf(bkt.Min, -1) && math.IsInf(bkt.Max, 1):
+		return "*"
+	case math.IsInf(bkt.Min, -1):
+		return "<" + f(bkt.Max)
+	case math.IsInf(bkt.Max, 1):
+		return ">" + f(bkt.Min)
+	case bkt.Min == bkt.Max:
+		return f(bkt.Min)
+	}
+	return f(bkt.Min) + "-" + f(bkt.Max)
+}
 
-	return tabw.Flush()
+// foldRange names the range spanned by a fold. Folds that reach the
+// underflow or overflow bucket are named by their finite bound only.
+func foldRange(span Bucket, f FormatFunc) string {
+	switch {
+	case math.IsInf(span.Min, -1) && math.IsInf(span.Max, 1):
+		return "all values"
+	case math.IsInf(span.Min, -1):
+		return "< " + f(span.Max)
+	case math.IsInf(span.Max, 1):
+		return "> " + f(span.Min)
+	}
+	return f(span.Min) + "–" + f(span.Max)
+}
+
+// fillFolds replaces the lines of b at the keys of folds by their
+// values.
+func fillFolds(b []byte, folds map[int]string) []byte {
+	var out []byte
+	for i, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if fold, ok := folds[i]; ok {
+			line = []byte(fold + "\n")
+		}
+		out = append(out, line...)
+	}
+	return out
+}
+
+// writeTrimmed writes the lines of b to w, without the padding that
+// tabwriter leaves at the end of lines whose last cells are empty.
+func writeTrimmed(w io.Writer, b []byte) error {
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		trimmed := bytes.TrimRight(line, " \n")
+		if bytes.HasSuffix(line, []byte("\n")) {
+			trimmed = append(trimmed, '\n')
+		}
+		if _, err := w.Write(trimmed); err != nil {
+			return err
+		}
+	}
+	return nil
 }
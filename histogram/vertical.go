@@ -0,0 +1,175 @@
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+var vblocks = []string{
+	" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█",
+}
+
+// FprintVertical prints an histogram on the io.Writer with a column
+// per bucket, stacked over `height` rows. The counts are marked on
+// the Y axis and the bucket edges along the X axis:
+//
+//	5 ┤  ▁     █▁
+//	  │  █     ██
+//	3 ┤  █     ██
+//	  │▆ █▆  ▆ ██▆   ▆ ▆▆
+//	0 └──────────────────
+//	   0.1 0.3 0.5 0.7  1
+func FprintVertical(w io.Writer, h Histogram, height int, opts ...Option) error {
+	return fprintVertical(w, h, height, defaultFormat, newOptions(opts))
+}
+
+// FprintVerticalf is the same as FprintVertical, but applies f to the
+// axis labels.
+func FprintVerticalf(w io.Writer, h Histogram, height int, f FormatFunc, opts ...Option) error {
+	return fprintVertical(w, h, height, f, newOptions(opts))
+}
+
+func fprintVertical(w io.Writer, h Histogram, height int, f FormatFunc, o options) error {
+	if height <= 0 || len(h.Buckets) == 0 {
+		return nil
+	}
+
+	h = o.trim(h)
+	if o.cumulative {
+		h = h.Cumulative()
+	}
+
+	// columns rise from 0, like the ticks of the Y axis
+	sizes := make([]float64, len(h.Buckets))
+	for i, bkt := range h.Buckets {
+		if h.Max > 0 {
+			sizes[i] = bkt.Count / h.Max * float64(height)
+		}
+	}
+
+	// ticks on the Y axis, at the top and middle rows
+	tick := formatCount
+	if o.cumulative {
+		tick = func(c float64) string {
+			return fmt.Sprintf("%.3g%%", c*100.0/h.Count)
+		}
+	}
+	ticks := make([]string, height)
+	ticks[0] = tick(h.Max)
+	if height >= 4 {
+		mid := height / 2
+		level := h.Max * float64(height-mid) / float64(height)
+		if h.Max == math.Trunc(h.Max) {
+			// counts of unweighted histograms are whole
+			level = math.Round(level)
+		}
+		ticks[mid] = tick(level)
+	}
+	ywidth := len(tick(0))
+	for _, tick := range ticks {
+		ywidth = imax(ywidth, len(tick))
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for row := 0; row < height; row++ {
+		axis := "│"
+		if ticks[row] != "" {
+			axis = "┤"
+		}
+		fmt.Fprintf(buf, "%*s %s", ywidth, ticks[row], axis)
+		// how many rows above the X axis this row is
+		level := float64(height - row - 1)
+		for _, sz := range sizes {
+			buf.WriteString(vblock(sz - level))
+		}
+		buf.WriteByte('\n')
+	}
+	fmt.Fprintf(buf, "%*s └%s\n", ywidth, tick(0), strings.Repeat("─", len(sizes)))
+	if rug := o.rugLine(h); rug != "" {
+		fmt.Fprintf(buf, "%*s  %s\n", ywidth, "", rug)
+	}
+	fmt.Fprintf(buf, "%*s  %s\n", ywidth, "", xlabels(h, f, 1))
+
+	if h.NaN > 0 {
+		fmt.Fprintf(buf, "NaN: %d\n", h.NaN)
+	}
+	if h.Inf > 0 {
+		fmt.Fprintf(buf, "±Inf: %d\n", h.Inf)
+	}
+	buf.WriteString(o.summaryBlock(f))
+
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// vblock gives the glyph filling v rows of a cell, v being clamped
+// to [0, 1].
+func vblock(v float64) string {
+	switch {
+	case v >= 1:
+		return vblocks[len(vblocks)-1]
+	case v <= 0:
+		return vblocks[0]
+	}
+	return vblocks[int(v*float64(len(vblocks)-1))]
+}
+
+// xlabels lays out the finite edges of the buckets of h along a line
+// with `colWidth` columns per bucket. The first and last edges are
+// always printed, the ones in between only if there's room for them.
+func xlabels(h Histogram, f FormatFunc, colWidth int) string {
+	type xlabel struct {
+		col  int
+		text string
+	}
+	n := len(h.Buckets)
+	edge := func(i int) float64 {
+		if i == n {
+			return h.Buckets[n-1].Max
+		}
+		return h.Buckets[i].Min
+	}
+
+	first, last := 0, n
+	for first < n && math.IsInf(edge(first), 0) {
+		first++
+	}
+	for last > first && math.IsInf(edge(last), 0) {
+		last--
+	}
+
+	var labels []xlabel
+	firstLabel := xlabel{col: first * colWidth, text: f(edge(first))}
+	labels = append(labels, firstLabel)
+	end := firstLabel.col + utf8.RuneCountInString(firstLabel.text)
+
+	var lastLabel *xlabel
+	if last > first {
+		text := f(edge(last))
+		col := imax(end+1, last*colWidth-utf8.RuneCountInString(text))
+		lastLabel = &xlabel{col: col, text: text}
+	}
+
+	for i := first + 1; i < last && lastLabel != nil; i++ {
+		text, col := f(edge(i)), i*colWidth
+		if col > end && col+utf8.RuneCountInString(text) < lastLabel.col {
+			labels = append(labels, xlabel{col: col, text: text})
+			end = col + utf8.RuneCountInString(text)
+		}
+	}
+	if lastLabel != nil {
+		labels = append(labels, *lastLabel)
+	}
+
+	var line strings.Builder
+	col := 0
+	for _, l := range labels {
+		line.WriteString(strings.Repeat(" ", l.col-col))
+		line.WriteString(l.text)
+		col = l.col + utf8.RuneCountInString(l.text)
+	}
+	return line.String()
+}
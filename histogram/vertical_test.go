@@ -0,0 +1,74 @@
+package histogram
+
+import (
+	"os"
+	"time"
+)
+
+func ExampleFprintVertical() {
+	data := []float64{
+		0.1,
+		0.2, 0.21, 0.22, 0.22,
+		0.3,
+		0.4,
+		0.5, 0.51, 0.52, 0.53, 0.54, 0.55, 0.56, 0.57, 0.58,
+		0.6,
+		// 0.7 is empty
+		0.8,
+		0.9,
+		1.0,
+	}
+
+	hist := Hist(18, data)
+
+	if err := FprintVertical(os.Stdout, hist, 4); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 5 ┤  ▁     █▁
+	//   │  █     ██
+	// 3 ┤  █     ██
+	//   │▆ █▆  ▆ ██▆   ▆ ▆▆
+	// 0 └──────────────────
+	//    0.1 0.3 0.5 0.7  1
+}
+
+func ExampleFprintVerticalf() {
+	rec := NewRecorder(LinearLayout(30, 0, float64(300*time.Millisecond)))
+	for i := 0; i < 1000; i++ {
+		// a bimodal distribution of latencies
+		v := time.Duration((i*7919)%100) * time.Millisecond
+		if i%3 == 0 {
+			v = 150*time.Millisecond + time.Duration((i*104729)%40)*time.Millisecond
+		}
+		rec.Record(float64(v))
+	}
+
+	err := FprintVerticalf(os.Stdout, rec.Snapshot(), 5, func(v float64) string {
+		return time.Duration(v).String()
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// 85 ┤                █▇▇▆
+	//    │ █▆▆█▇▆▇█▆▇     ████
+	// 51 ┤ ██████████     ████
+	//    │ ██████████     ████
+	//    │ ██████████     ████
+	//  0 └────────────────────────────────
+	//      0s 30ms 80ms 130ms 190ms 300ms
+}
+
+func ExampleFprintVertical_single() {
+	if err := FprintVertical(os.Stdout, Hist(3, []float64{2, 2, 2}), 4); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 3 ┤█
+	//   │█
+	// 2 ┤█
+	//   │█
+	// 0 └─
+	//    2 2
+}
@@ -0,0 +1,28 @@
+// Package bars draws the bars of the plots of uniplot with sets of
+// glyphs.
+package bars
+
+import (
+	"math"
+	"strings"
+)
+
+// Set lists the glyphs that bars are drawn with, from the thinnest to
+// the full glyph. A bar is drawn with full glyphs, followed by a glyph
+// as thick as the remaining fraction of the bar.
+type Set []string
+
+var (
+	// Blocks draws bars with eighths of Unicode blocks, `▏▎▍▌▋▊▉█`.
+	Blocks = Set{"▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+	// ASCII draws bars with ASCII characters, `.-=#`, for terminals
+	// and fonts that lack Unicode blocks.
+	ASCII = Set{".", "-", "=", "#"}
+)
+
+// Bar draws a bar of size v, which must have at least one glyph.
+func (s Set) Bar(v float64) string {
+	tenths := math.Floor((v - math.Floor(v)) * 10.0)
+	idx := int(tenths / 10.0 * float64(len(s)))
+	return strings.Repeat(s[len(s)-1], int(v)) + s[idx]
+}
@@ -0,0 +1,49 @@
+// Package numeric holds what the plots of uniplot share to take
+// numbers of any type.
+package numeric
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Number is the constraint of the values that plots take without
+// converting them first. It includes time.Duration and other types
+// defined on numbers.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// FormatDuration prints v nanoseconds as a time.Duration, rounded to
+// 3 significant digits. NaN and infinite values print like floats.
+func FormatDuration(v float64) string {
+	switch {
+	case v == 0:
+		return "0s"
+	case math.IsInf(v, 0), math.IsNaN(v):
+		return strconv.FormatFloat(v, 'g', 4, 64)
+	}
+	unit := math.Pow(10, math.Floor(math.Log10(math.Abs(v)))-2)
+	if unit < 1 {
+		unit = 1
+	}
+	return time.Duration(math.Round(v/unit) * unit).String()
+}
+
+// ToInt converts v to an int, rounding floats to the nearest int. It
+// reports whether v fits in an int.
+func ToInt[T Number](v T) (int, bool) {
+	if f := float64(v); f != math.Trunc(f) || math.IsInf(f, 0) {
+		// only floats have a fractional part, or are NaN or infinite
+		f = math.Round(f)
+		if !(f >= math.MinInt && f < -math.MinInt) {
+			return 0, false
+		}
+		return int(f), true
+	}
+	i := int(v)
+	return i, T(i) == v && (i < 0) == (v < 0)
+}
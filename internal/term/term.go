@@ -0,0 +1,29 @@
+// Package term tells the width of the terminals that the plots of
+// uniplot are printed on.
+package term
+
+import (
+	"io"
+	"os"
+
+	"github.com/aybabtme/uniplot/spark/ts"
+)
+
+// DefaultWidth is the width lines fit in when the size of the terminal
+// can't be told.
+const DefaultWidth = 80
+
+// Width gives the number of columns of the terminal that w writes to.
+// If w isn't a terminal, the terminal of the standard output is used,
+// or DefaultWidth if there's none.
+func Width(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok {
+		f = os.Stdout
+	}
+	size, err := ts.GetSize(f)
+	if err != nil || size.Col() <= 0 {
+		return DefaultWidth
+	}
+	return size.Col()
+}